@@ -0,0 +1,224 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/spf13/cobra"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/fileperm"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/versionmanager"
+	"github.com/tofuutils/tenv/v4/versionmanager/builder"
+	"github.com/tofuutils/tenv/v4/versionmanager/bundle"
+	"github.com/tofuutils/tenv/v4/versionmanager/projectlock"
+	versionfinder "github.com/tofuutils/tenv/v4/versionmanager/semantic/finder"
+)
+
+const (
+	bundleHelp       = "Subcommand to move installed versions between machines (like air-gapped ones)."
+	bundleCreateHelp = "Pack installed versions with their checksums in a tar.gz bundle."
+	bundleImportHelp = "Verify a bundle and unpack its versions in TENV_ROOT."
+
+	defaultBundleName = "tenv-bundle.tar.gz"
+	sumFileSuffix     = ".sha256"
+)
+
+var (
+	errInvalidVersion = errors.New("invalid version")
+	errUnknownTool    = errors.New("unknown tool")
+)
+
+func newBundleCmd(conf *config.Config, hclParser *hclparse.Parser) *cobra.Command {
+	bundleCmd := &cobra.Command{
+		Use:   "bundle",
+		Short: bundleHelp,
+		Long:  bundleHelp,
+	}
+
+	bundleCmd.AddCommand(newBundleCreateCmd(conf, hclParser))
+	bundleCmd.AddCommand(newBundleImportCmd(conf, hclParser))
+
+	return bundleCmd
+}
+
+func newBundleCreateCmd(conf *config.Config, hclParser *hclparse.Parser) *cobra.Command {
+	toolNames := slices.Sorted(maps.Keys(builder.Builders))
+
+	var descBuilder strings.Builder
+	descBuilder.WriteString(bundleCreateHelp)
+	descBuilder.WriteString("\n\nParameters select tools (")
+	descBuilder.WriteString(strings.Join(toolNames, ", "))
+	descBuilder.WriteString(`) with all their installed versions, or a specific installed version with <tool>@<version> format.
+Without parameter, all installed versions of all tools are bundled.
+
+With --project flag, each selected tool (all tools without parameter) is resolved from project version files (including `)
+	descBuilder.WriteString(projectlock.FileName)
+	descBuilder.WriteString(`) against installed versions.
+
+The upstream files giving the release archive checksum of each version (checksums file and signatures) are bundled too.
+
+The sha256 of the bundle is displayed and written next to it in a .sha256 file (to check it with import --sha256 flag).`)
+
+	outputPath := defaultBundleName
+	project := false
+
+	createCmd := &cobra.Command{
+		Use:          "create [tool[@version]]...",
+		Short:        bundleCreateHelp,
+		Long:         descBuilder.String(),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			conf.InitDisplayer(false)
+			offline := conf.Offline
+			conf.Offline = true // only installed versions can be bundled
+			conf.SkipInstall = true
+
+			if len(args) == 0 {
+				args = toolNames
+			}
+
+			ctx := context.Background()
+			items, err := selectBundleItems(ctx, conf, hclParser, args, project)
+			if err != nil {
+				return err
+			}
+
+			conf.Offline = offline // upstream checksums files can be downloaded
+			sum, err := bundle.Create(ctx, conf, outputPath, items)
+			if err != nil {
+				return err
+			}
+
+			hexSum := hex.EncodeToString(sum)
+			sumContent := loghelper.Concat(hexSum, "  ", filepath.Base(outputPath), "\n")
+			if err = os.WriteFile(outputPath+sumFileSuffix, []byte(sumContent), fileperm.RW); err != nil {
+				return err
+			}
+
+			loghelper.StdDisplay(loghelper.Concat("Written ", outputPath, " (sha256 ", hexSum, ")"))
+
+			return nil
+		},
+	}
+
+	flags := createCmd.Flags()
+	flags.StringVarP(&outputPath, "output", "o", outputPath, "path of the bundle to write")
+	flags.BoolVarP(&project, "project", "p", false, "select versions resolved from project version files")
+
+	return createCmd
+}
+
+func newBundleImportCmd(conf *config.Config, hclParser *hclparse.Parser) *cobra.Command {
+	expectedSumStr := ""
+	force := false
+
+	importCmd := &cobra.Command{
+		Use:   "import bundle-path",
+		Short: bundleImportHelp,
+		Long: bundleImportHelp + `
+
+The sha256 of the bundle is mandatory and must be obtained through a trusted channel (distinct from the bundle one),
+then each bundled file is checked against the bundle manifest and the archive checksum recorded for each version
+against the bundled upstream checksums file (with its signature checked) before any installation,
+already installed versions are kept unless --force flag is used (incomplete installations are always replaced).`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			conf.InitDisplayer(false)
+
+			expectedSum, err := hex.DecodeString(expectedSumStr)
+			if err != nil {
+				return err
+			}
+
+			managers := make(map[string]versionmanager.VersionManager, len(builder.Builders))
+			for toolName, builderFunc := range builder.Builders {
+				managers[toolName] = builderFunc(conf, hclParser)
+			}
+
+			_, err = bundle.Import(context.Background(), conf, args[0], expectedSum, managers, force)
+
+			return err
+		},
+	}
+
+	flags := importCmd.Flags()
+	flags.StringVarP(&expectedSumStr, "sha256", "s", "", "expected sha256 of the bundle (hex encoded)")
+	flags.BoolVarP(&force, "force", "f", false, "replace already installed versions")
+	_ = importCmd.MarkFlagRequired("sha256")
+
+	return importCmd
+}
+
+func selectBundleItems(ctx context.Context, conf *config.Config, hclParser *hclparse.Parser, args []string, project bool) ([]bundle.Item, error) {
+	var items []bundle.Item
+	for _, arg := range args {
+		toolName, version, withVersion := strings.Cut(arg, "@")
+		builderFunc, ok := builder.Builders[toolName]
+		if !ok {
+			return nil, fmt.Errorf("%w : %s", errUnknownTool, toolName)
+		}
+
+		versionManager := builderFunc(conf, hclParser)
+		switch {
+		case withVersion:
+			if !versionfinder.IsValid(version) {
+				return nil, fmt.Errorf("%w : %s", errInvalidVersion, version)
+			}
+			items = append(items, bundle.Item{Manager: versionManager, Tool: toolName, Version: versionfinder.Clean(version)})
+		case project:
+			resolved, err := versionManager.ResolveWithVersionFiles()
+			if err != nil {
+				return nil, err
+			}
+
+			if resolved == "" {
+				continue
+			}
+
+			if version, err = versionManager.Evaluate(ctx, resolved, false); err != nil {
+				return nil, err
+			}
+			items = append(items, bundle.Item{Manager: versionManager, Tool: toolName, Version: version})
+		default:
+			datedVersions, err := versionManager.ListLocal(false)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, datedVersion := range datedVersions {
+				items = append(items, bundle.Item{Manager: versionManager, Tool: toolName, Version: datedVersion.Version})
+			}
+		}
+	}
+
+	return items, nil
+}
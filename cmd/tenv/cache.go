@@ -0,0 +1,174 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/config/envname"
+	configutils "github.com/tofuutils/tenv/v4/config/utils"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+const (
+	cacheHelp      = "Subcommand to manage the download cache (shared between root paths)."
+	cacheClearHelp = "Remove every entry of the download cache."
+	cacheListHelp  = "List entries of the download cache, most recently used first."
+	cachePruneHelp = "Remove least recently used entries of the download cache to respect its size limit."
+)
+
+var errCacheDisabled = errors.New("download cache is disabled (" + envname.TenvSkipCache + " is set)")
+
+func newCacheCmd(conf *config.Config) *cobra.Command {
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: cacheHelp,
+		Long: cacheHelp + `
+
+Downloaded release files are stored once by sha256 (and indexed by their URL) in TENV_CACHE_DIR directory,
+the cache is consulted before any release download.`,
+	}
+
+	cacheCmd.AddCommand(newCacheClearCmd(conf))
+	cacheCmd.AddCommand(newCacheListCmd(conf))
+	cacheCmd.AddCommand(newCachePruneCmd(conf))
+
+	return cacheCmd
+}
+
+func newCacheClearCmd(conf *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:          "clear",
+		Short:        cacheClearHelp,
+		Long:         cacheClearHelp,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cache, err := initCache(conf)
+			if err != nil {
+				return err
+			}
+
+			if err = cache.Clear(); err != nil {
+				return err
+			}
+
+			loghelper.StdDisplay("Cleared " + cache.DirPath())
+
+			return nil
+		},
+	}
+}
+
+func newCacheListCmd(conf *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:          "list",
+		Short:        cacheListHelp,
+		Long:         cacheListHelp,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cache, err := initCache(conf)
+			if err != nil {
+				return err
+			}
+
+			entries, err := cache.List()
+			if err != nil {
+				return err
+			}
+
+			var totalSize int64
+			for _, entry := range entries {
+				totalSize += entry.Size
+				loghelper.StdDisplay(loghelper.Concat(entry.SHA256, " ", loghelper.FormatSize(entry.Size), " (used ", entry.LastUse.Format(time.DateOnly), ")"))
+				for _, url := range entry.URLs {
+					loghelper.StdDisplay("  " + url)
+				}
+			}
+
+			if conf.DisplayVerbose {
+				loghelper.StdDisplay(loghelper.Concat("found ", strconv.Itoa(len(entries)), " entries in ", cache.DirPath(), ", total size ", loghelper.FormatSize(totalSize), " (limit ", formatLimit(cache.MaxSize()), ")."))
+			}
+
+			return nil
+		},
+	}
+}
+
+func newCachePruneCmd(conf *config.Config) *cobra.Command {
+	maxSizeStr := ""
+
+	pruneCmd := &cobra.Command{
+		Use:          "prune",
+		Short:        cachePruneHelp,
+		Long:         cachePruneHelp,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cache, err := initCache(conf)
+			if err != nil {
+				return err
+			}
+
+			maxSize := cache.MaxSize()
+			if maxSizeStr != "" {
+				if maxSize, err = configutils.ParseSize(maxSizeStr); err != nil {
+					return err
+				}
+			}
+
+			removed, err := cache.Prune(maxSize)
+			for _, entry := range removed {
+				loghelper.StdDisplay(loghelper.Concat("Removed ", entry.SHA256, " ", loghelper.FormatSize(entry.Size)))
+			}
+
+			return err
+		},
+	}
+
+	pruneCmd.Flags().StringVarP(&maxSizeStr, "max-size", "m", "", "size limit to respect (override "+envname.TenvCacheMax+", like 500M or 2G)")
+
+	return pruneCmd
+}
+
+func formatLimit(maxSize int64) string {
+	if maxSize <= 0 {
+		return "unlimited"
+	}
+
+	return loghelper.FormatSize(maxSize)
+}
+
+func initCache(conf *config.Config) (*download.Cache, error) {
+	conf.InitDisplayer(false)
+
+	cache := conf.DownloadCache()
+	if cache == nil {
+		return nil, errCacheDisabled
+	}
+
+	return cache, nil
+}
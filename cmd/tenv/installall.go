@@ -0,0 +1,116 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/versionmanager"
+	"github.com/tofuutils/tenv/v4/versionmanager/builder"
+)
+
+const installAllHelp = "Install every tool version detected for the working directory."
+
+func newInstallAllCmd(conf *config.Config, hclParser *hclparse.Parser) *cobra.Command {
+	toolNames := slices.Sorted(maps.Keys(builder.Builders))
+
+	var descBuilder strings.Builder
+	descBuilder.WriteString(installAllHelp)
+	descBuilder.WriteString("\n\nThe version of each tool (")
+	descBuilder.WriteString(strings.Join(toolNames, ", "))
+	descBuilder.WriteString(`) is resolved from its version environment variables or version files
+(searched in working directory, its parents, user home directory or TENV_ROOT directory), tools without detected version are skipped.
+
+Missing versions are then installed in parallel (at most TENV_INSTALL_CONCURRENCY at once, 4 by default).`)
+
+	concurrency := 0
+
+	installAllCmd := &cobra.Command{
+		Use:          "install-all",
+		Short:        installAllHelp,
+		Long:         descBuilder.String(),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			conf.InitDisplayer(false)
+			if concurrency > 0 {
+				conf.InstallConc = concurrency
+			}
+
+			ctx := context.Background()
+			// resolution is sequential (hclParser is not safe for concurrent use)
+			versionManagers := make([]versionmanager.VersionManager, 0, len(toolNames))
+			versions := make([]string, 0, len(toolNames))
+			for _, toolName := range toolNames {
+				versionManager := builder.Builders[toolName](conf, hclParser)
+				resolved, err := versionManager.Resolve("")
+				if err != nil {
+					if errors.Is(err, versionmanager.ErrNoVersionFilesFound) {
+						continue
+					}
+
+					return err
+				}
+
+				version, err := versionManager.EvaluateWithoutInstall(ctx, resolved)
+				if err != nil {
+					return err
+				}
+
+				versionManagers = append(versionManagers, versionManager)
+				versions = append(versions, version)
+			}
+
+			if len(versions) == 0 {
+				loghelper.StdDisplay("No tool version detected")
+
+				return nil
+			}
+
+			if err := conf.InitRemoteConf(); err != nil {
+				return err
+			}
+
+			group, groupCtx := errgroup.WithContext(ctx)
+			group.SetLimit(max(conf.InstallConc, 1))
+			for index, versionManager := range versionManagers {
+				group.Go(func() error {
+					return versionManager.InstallMultiple(groupCtx, versions[index:index+1])
+				})
+			}
+
+			return group.Wait()
+		},
+	}
+
+	flags := installAllCmd.Flags()
+	flags.IntVarP(&concurrency, "concurrency", "c", 0, "maximum number of parallel installations (override TENV_INSTALL_CONCURRENCY)")
+
+	return installAllCmd
+}
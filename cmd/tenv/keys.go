@@ -0,0 +1,156 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/config/cmdconst"
+	pgpcheck "github.com/tofuutils/tenv/v4/pkg/check/pgp"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+const (
+	keysHelp       = "Subcommand to manage the trust store of PGP public keys (in TENV_ROOT/keys)."
+	keysAddHelp    = "Add PGP public keys to the trust store of a tool, from a local file or an URL."
+	keysListHelp   = "List PGP public keys of the trust store, with their expiry status."
+	keysRemoveHelp = "Remove PGP public keys from the trust store of a tool, by fingerprint."
+)
+
+var keysToolNames = []string{cmdconst.TerraformName, cmdconst.TofuName} //nolint
+
+func newKeysCmd(conf *config.Config) *cobra.Command {
+	keysCmd := &cobra.Command{
+		Use:   "keys",
+		Short: keysHelp,
+		Long: keysHelp + `
+
+When the trust store of a tool is not empty, its keys replace the ones of TFENV_HASHICORP_PGP_KEY or TOFUENV_OPENTOFU_PGP_KEY
+to check signatures (revoked and expired keys are skipped, keys expiring within 30 days are reported).
+
+Each key is stored in a file named after its fingerprint, which is checked when the key is loaded.`,
+	}
+
+	keysCmd.AddCommand(newKeysAddCmd(conf))
+	keysCmd.AddCommand(newKeysListCmd(conf))
+	keysCmd.AddCommand(newKeysRemoveCmd(conf))
+
+	return keysCmd
+}
+
+func newKeysAddCmd(conf *config.Config) *cobra.Command {
+	fingerprint := ""
+
+	addCmd := &cobra.Command{
+		Use:   "add tool path-or-url",
+		Short: keysAddHelp,
+		Long: keysAddHelp + `
+
+With --fingerprint flag, only the key with that fingerprint is added (and the command fail when it is absent).`,
+		ValidArgs:    keysToolNames,
+		Args:         cobra.MatchAll(cobra.ExactArgs(2), validKeysTool),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			conf.InitDisplayer(false)
+
+			dataPublicKey, err := download.GetPGPKey(context.Background(), args[1], conf.Displayer.Display)
+			if err != nil {
+				return err
+			}
+
+			infos, err := conf.PGPKeyStore(args[0]).Add(dataPublicKey, fingerprint)
+			for _, info := range infos {
+				loghelper.StdDisplay(loghelper.Concat("Added ", info.Fingerprint, " ", info.UserID, " (", info.Status(time.Now()), ")"))
+			}
+
+			return err
+		},
+	}
+
+	addCmd.Flags().StringVarP(&fingerprint, "fingerprint", "f", "", "expected fingerprint of the key to add")
+
+	return addCmd
+}
+
+func newKeysListCmd(conf *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:          "list [tool]...",
+		Short:        keysListHelp,
+		Long:         keysListHelp + "\n\nWithout parameter, keys of all tools are listed.",
+		ValidArgs:    keysToolNames,
+		Args:         cobra.OnlyValidArgs,
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			conf.InitDisplayer(false)
+
+			selectedNames := keysToolNames
+			if len(args) != 0 {
+				selectedNames = args
+			}
+
+			now := time.Now()
+			for _, toolName := range selectedNames {
+				infos, err := conf.PGPKeyStore(toolName).List()
+				if err != nil {
+					return err
+				}
+
+				for _, info := range infos {
+					loghelper.StdDisplay(loghelper.Concat(toolName, " ", info.Fingerprint, " ", info.UserID, " (", info.Status(now), ")"))
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func newKeysRemoveCmd(conf *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:          "remove tool fingerprint...",
+		Short:        keysRemoveHelp,
+		Long:         keysRemoveHelp,
+		ValidArgs:    keysToolNames,
+		Args:         cobra.MatchAll(cobra.MinimumNArgs(2), validKeysTool),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			conf.InitDisplayer(false)
+
+			store := conf.PGPKeyStore(args[0])
+			for _, fingerprint := range args[1:] {
+				if err := store.Remove(fingerprint); err != nil {
+					return err
+				}
+
+				loghelper.StdDisplay("Removed " + pgpcheck.NormalizeFingerprint(fingerprint))
+			}
+
+			return nil
+		},
+	}
+}
+
+func validKeysTool(cmd *cobra.Command, args []string) error {
+	return cobra.OnlyValidArgs(cmd, args[:1])
+}
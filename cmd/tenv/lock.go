@@ -0,0 +1,165 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/spf13/cobra"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/versionmanager"
+	"github.com/tofuutils/tenv/v4/versionmanager/builder"
+	"github.com/tofuutils/tenv/v4/versionmanager/projectlock"
+	"github.com/tofuutils/tenv/v4/versionmanager/semantic"
+)
+
+const lockHelp = "Write a project lock file pinning exact versions and archive checksums."
+
+func newLockCmd(conf *config.Config, hclParser *hclparse.Parser) *cobra.Command {
+	toolNames := slices.Sorted(maps.Keys(builder.Builders))
+
+	var descBuilder strings.Builder
+	descBuilder.WriteString(lockHelp)
+	descBuilder.WriteString("\n\nResolve the version of each tool (")
+	descBuilder.WriteString(strings.Join(toolNames, ", "))
+	descBuilder.WriteString(") from its version files, then write its exact version and its release archive sha256 in ")
+	descBuilder.WriteString(projectlock.FileName)
+	descBuilder.WriteString(` file (the nearest one found in working directory or its parents, created in working directory when none are found).
+
+Without parameter, only tools with a detected version are locked (already locked tools are kept).
+If parameters are passed, they select tools to lock (resolved with latest-allowed strategy when no version is detected).
+
+Once written, this file takes precedence over other version files and installations fail on checksum mismatch.`)
+
+	var platforms []string
+	update := false
+
+	lockCmd := &cobra.Command{
+		Use:          "lock [tool]...",
+		Short:        lockHelp,
+		Long:         descBuilder.String(),
+		ValidArgs:    toolNames,
+		Args:         cobra.OnlyValidArgs,
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			conf.InitDisplayer(false)
+
+			filePath, lock, err := projectlock.Search(conf)
+			if err != nil {
+				return err
+			}
+
+			if filePath == "" {
+				filePath = filepath.Join(conf.WorkPath, projectlock.FileName)
+				lock = projectlock.Lock{Tools: map[string]projectlock.ToolLock{}}
+			}
+
+			platforms = append(platforms, projectlock.PlatformKey(runtime.GOOS, conf.Arch))
+
+			ctx := context.Background()
+			selectedNames := toolNames
+			if len(args) != 0 {
+				selectedNames = args
+			}
+
+			for _, toolName := range selectedNames {
+				toolLock, err := lockTool(ctx, builder.Builders[toolName](conf, hclParser), lock.Tools[toolName], len(args) != 0, update, platforms)
+				if err != nil {
+					return err
+				}
+
+				if toolLock.Version != "" {
+					lock.Tools[toolName] = toolLock
+				}
+			}
+
+			if err = projectlock.Write(filePath, lock); err != nil {
+				return err
+			}
+
+			loghelper.StdDisplay(loghelper.Concat("Written ", filePath))
+
+			return nil
+		},
+	}
+
+	flags := lockCmd.Flags()
+	flags.StringSliceVarP(&platforms, "platform", "p", nil, "additional platform (<os>_<arch> format, like linux_amd64) to pin checksum for (can be repeated)")
+	flags.BoolVarP(&update, "update", "u", false, "resolve again versions of already locked tools")
+
+	return lockCmd
+}
+
+func lockTool(ctx context.Context, versionManager versionmanager.VersionManager, previous projectlock.ToolLock, requested bool, update bool, platforms []string) (projectlock.ToolLock, error) {
+	version := previous.Version
+	if version == "" || update {
+		resolved, err := versionManager.ResolveWithoutLock()
+		if err != nil {
+			return projectlock.ToolLock{}, err
+		}
+
+		if resolved == "" {
+			if !requested {
+				return previous, nil
+			}
+			resolved = semantic.LatestAllowedKey
+		}
+
+		if version, err = versionManager.EvaluateWithoutInstall(ctx, resolved); err != nil {
+			return projectlock.ToolLock{}, err
+		}
+	}
+
+	checksums := map[string]string{}
+	if version == previous.Version {
+		// keep platforms previously pinned up to date
+		for platform := range previous.Checksums {
+			checksums[platform] = ""
+		}
+	}
+	for _, platform := range platforms {
+		checksums[platform] = ""
+	}
+
+	for platform := range checksums {
+		goos, arch, ok := strings.Cut(platform, "_")
+		if !ok {
+			return projectlock.ToolLock{}, errors.New(loghelper.Concat("invalid platform ", platform, ", expect <os>_<arch> format"))
+		}
+
+		checksum, err := versionManager.Checksum(ctx, version, goos, arch)
+		if err != nil {
+			return projectlock.ToolLock{}, err
+		}
+		checksums[platform] = checksum
+	}
+
+	versionManager.Conf.Displayer.Display(loghelper.Concat("Locked ", versionManager.FolderName, " ", version))
+
+	return projectlock.ToolLock{Version: version, Checksums: checksums}, nil
+}
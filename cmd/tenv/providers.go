@@ -0,0 +1,136 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/spf13/cobra"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/versionmanager/providermirror"
+	iacparser "github.com/tofuutils/tenv/v4/versionmanager/semantic/parser/iac"
+)
+
+const (
+	providersHelp       = "Subcommand to manage Terraform and OpenTofu providers."
+	providersMirrorHelp = "Download providers required by the project into a plugin cache (in TENV_ROOT/providers)."
+)
+
+var errPlatform = errors.New("invalid platform, expected <os>_<arch> format")
+
+func newProvidersCmd(conf *config.Config, hclParser *hclparse.Parser) *cobra.Command {
+	providersCmd := &cobra.Command{
+		Use:   "providers",
+		Short: providersHelp,
+		Long:  providersHelp,
+	}
+
+	providersCmd.AddCommand(newProvidersMirrorCmd(conf, hclParser))
+
+	return providersCmd
+}
+
+func newProvidersMirrorCmd(conf *config.Config, hclParser *hclparse.Parser) *cobra.Command {
+	var platforms []string
+	registry := providermirror.DefaultHost
+
+	mirrorCmd := &cobra.Command{
+		Use:   "mirror",
+		Short: providersMirrorHelp,
+		Long: providersMirrorHelp + `
+
+Providers are gathered from required_providers blocks of IAC files (.tf, .tf.json, .tofu and .tofu.json) in working directory
+and from ` + providermirror.LockFileName + ` file, where versions and hashes are taken from when present.
+Otherwise the highest version matching constraints is selected.
+
+Each archive is checked against the checksum published by its registry and against the lock file hashes.
+Set TF_PLUGIN_CACHE_DIR to the plugin cache path to make "init" use downloaded providers.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			conf.InitDisplayer(false)
+
+			iacExts := []iacparser.ExtDescription{
+				{Value: ".tofu", Parser: hclParser.ParseHCLFile},
+				{Value: ".tofu.json", Parser: hclParser.ParseJSONFile},
+				{Value: ".tf", Parser: hclParser.ParseHCLFile},
+				{Value: ".tf.json", Parser: hclParser.ParseJSONFile},
+			}
+			requirements, err := iacparser.GatherRequiredProviders(conf, iacExts)
+			if err != nil {
+				return err
+			}
+
+			locked, err := providermirror.ReadLockFile(filepath.Join(conf.WorkPath, providermirror.LockFileName))
+			if err != nil {
+				return err
+			}
+
+			providers, err := providermirror.Collect(requirements, locked, strings.ToLower(registry))
+			if err != nil {
+				return err
+			}
+
+			if len(providers) == 0 {
+				loghelper.StdDisplay("No provider found")
+
+				return nil
+			}
+
+			ctx := context.Background()
+			dirPath := conf.ProvidersCachePath()
+			for _, platform := range append([]string{runtime.GOOS + "_" + conf.Arch}, platforms...) {
+				goos, arch, ok := strings.Cut(platform, "_")
+				if !ok || goos == "" || arch == "" {
+					return errPlatform
+				}
+
+				results, err := providermirror.Mirror(ctx, conf, providers, dirPath, goos, arch)
+				for _, result := range results {
+					status := "Mirrored "
+					if result.Skipped {
+						status = "Already mirrored "
+					}
+					loghelper.StdDisplay(loghelper.Concat(status, result.Address.String(), " ", result.Version, " (", platform, ", ", result.Hash, ")"))
+				}
+
+				if err != nil {
+					return err
+				}
+			}
+
+			loghelper.StdDisplay("Set TF_PLUGIN_CACHE_DIR=" + dirPath + " to use them")
+
+			return nil
+		},
+	}
+
+	flags := mirrorCmd.Flags()
+	flags.StringSliceVarP(&platforms, "platform", "p", nil, "additional platform (<os>_<arch> format, like linux_amd64) to download providers for (can be repeated)")
+	flags.StringVar(&registry, "registry", registry, "registry host of providers without explicit host in their source address")
+
+	return mirrorCmd
+}
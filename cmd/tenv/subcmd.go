@@ -21,6 +21,7 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"os"
 	"strconv"
@@ -187,6 +188,63 @@ If a parameter is passed, available options:
 	return installCmd
 }
 
+func newInfoCmd(versionManager versionmanager.VersionManager) *cobra.Command {
+	conf := versionManager.Conf
+
+	var descBuilder strings.Builder
+	descBuilder.WriteString("Display provenance of an installed ")
+	descBuilder.WriteString(versionManager.FolderName)
+	descBuilder.WriteString(` version (read from its manifest.json file) :
+source url, archive sha256, validation really applied (sign, sha or none), signature identity, installation date and tenv version.`)
+
+	jsonOutput := false
+
+	infoCmd := &cobra.Command{
+		Use:          "info version",
+		Short:        loghelper.Concat("Display provenance of an installed ", versionManager.FolderName, " version."),
+		Long:         descBuilder.String(),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			conf.InitDisplayer(false)
+
+			installManifest, err := versionManager.Info(args[0])
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				data, err := json.MarshalIndent(installManifest, "", "  ")
+				if err != nil {
+					return err
+				}
+				loghelper.StdDisplay(string(data))
+
+				return nil
+			}
+
+			identity := installManifest.SignatureIdentity
+			if identity == "" {
+				identity = "none"
+			}
+
+			loghelper.StdDisplay(loghelper.Concat(installManifest.Tool, " ", installManifest.Version))
+			loghelper.StdDisplay("  source url     : " + installManifest.SourceURL)
+			loghelper.StdDisplay("  archive sha256 : " + installManifest.ArchiveSHA256)
+			loghelper.StdDisplay("  validation     : " + installManifest.Validation.String())
+			loghelper.StdDisplay("  signed by      : " + identity)
+			loghelper.StdDisplay("  installed at   : " + installManifest.InstalledAt.Format(time.RFC3339))
+			loghelper.StdDisplay("  tenv version   : " + installManifest.TenvVersion)
+
+			return nil
+		},
+	}
+
+	infoCmd.Flags().BoolVarP(&jsonOutput, "json", "j", false, "display raw manifest in json")
+
+	return infoCmd
+}
+
 func newListCmd(versionManager versionmanager.VersionManager) *cobra.Command {
 	conf := versionManager.Conf
 
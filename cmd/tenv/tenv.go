@@ -20,8 +20,10 @@ package main
 
 import (
 	"context"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2/hclparse"
@@ -41,12 +43,15 @@ const (
 	rootVersionHelp = "Display tenv current version."
 	updatePathHelp  = "Display PATH updated with tenv directory location first."
 
-	helpPrefix = "Subcommand to manage several versions of "
-	atmosHelp  = helpPrefix + "Atmos (https://atmos.tools)."
-	tfHelp     = helpPrefix + "Terraform (https://www.terraform.io)."
-	tgHelp     = helpPrefix + "Terragrunt (https://terragrunt.gruntwork.io)."
-	tmHelp     = helpPrefix + "Terramate (https://terramate.io)."
-	tofuHelp   = helpPrefix + "OpenTofu (https://opentofu.org)."
+	helpPrefix    = "Subcommand to manage several versions of "
+	atmosHelp     = helpPrefix + "Atmos (https://atmos.tools)."
+	infracostHelp = helpPrefix + "Infracost (https://www.infracost.io)."
+	tfHelp        = helpPrefix + "Terraform (https://www.terraform.io)."
+	tfDocsHelp    = helpPrefix + "terraform-docs (https://terraform-docs.io)."
+	tgHelp        = helpPrefix + "Terragrunt (https://terragrunt.gruntwork.io)."
+	tflintHelp    = helpPrefix + "TFLint (https://github.com/terraform-linters/tflint)."
+	tmHelp        = helpPrefix + "Terramate (https://terramate.io)."
+	tofuHelp      = helpPrefix + "OpenTofu (https://opentofu.org)."
 
 	pathEnvName = "PATH"
 
@@ -69,8 +74,17 @@ func main() {
 		loghelper.StdDisplay(loghelper.Concat("Configuration error : ", err.Error()))
 		os.Exit(1)
 	}
+	conf.TenvVersion = version
 
 	hclParser := hclparse.NewParser()
+	if err = conf.InitToolDefs(reservedNames(&conf, hclParser)); err != nil {
+		loghelper.StdDisplay(loghelper.Concat("Configuration error : ", err.Error()))
+		os.Exit(1)
+	}
+	builder.AddDeclaredTools(&conf)
+	conf.InitPlugins(reservedNames(&conf, hclParser))
+	builder.AddPluginTools(&conf)
+
 	manageNoArgsCmd(&conf, hclParser)     // call os.Exit when necessary
 	manageHiddenCallCmd(&conf, hclParser) // proxy call use os.Exit when called
 
@@ -87,12 +101,20 @@ func initRootCmd(conf *config.Config, hclParser *hclparse.Parser) *cobra.Command
 	}
 
 	flags := rootCmd.PersistentFlags()
+	flags.BoolVar(&conf.Offline, "offline", conf.Offline, "never access network (only installed versions and download cache are used)")
 	flags.BoolVarP(&conf.ForceQuiet, "quiet", "q", conf.ForceQuiet, "no unnecessary output (and no log)")
 	flags.StringVarP(&conf.RootPath, "root-path", "r", conf.RootPath, "local path to install versions of OpenTofu, Terraform, Terragrunt, and Atmos")
 	flags.BoolVarP(&conf.DisplayVerbose, "verbose", "v", false, "verbose output (and set log level to Trace)")
 
 	rootCmd.AddCommand(newVersionCmd())
+	rootCmd.AddCommand(newBundleCmd(conf, hclParser))
+	rootCmd.AddCommand(newCacheCmd(conf))
+	rootCmd.AddCommand(newInstallAllCmd(conf, hclParser))
+	rootCmd.AddCommand(newKeysCmd(conf))
+	rootCmd.AddCommand(newLockCmd(conf, hclParser))
+	rootCmd.AddCommand(newProvidersCmd(conf, hclParser))
 	rootCmd.AddCommand(newUpdatePathCmd(conf.GithubActions))
+	rootCmd.AddCommand(newVerifyCmd(conf, hclParser))
 
 	tofuCmd := &cobra.Command{
 		Use:     cmdconst.TofuName,
@@ -166,9 +188,94 @@ func initRootCmd(conf *config.Config, hclParser *hclparse.Parser) *cobra.Command
 
 	rootCmd.AddCommand(tmCmd)
 
+	tflintCmd := &cobra.Command{
+		Use:   cmdconst.TflintName,
+		Short: tflintHelp,
+		Long:  tflintHelp,
+	}
+
+	tflintParams := subCmdParams{
+		needToken: true, remoteEnvName: envname.TflintRemoteURL, pRemote: &conf.Tflint.RemoteURL,
+	}
+	initSubCmds(tflintCmd, builder.BuildTflintManager(conf, hclParser), tflintParams)
+
+	rootCmd.AddCommand(tflintCmd)
+
+	tfDocsCmd := &cobra.Command{
+		Use:   cmdconst.TerraformDocsName,
+		Short: tfDocsHelp,
+		Long:  tfDocsHelp,
+	}
+
+	tfDocsParams := subCmdParams{
+		needToken: true, remoteEnvName: envname.TfDocsRemoteURL, pRemote: &conf.TfDocs.RemoteURL,
+	}
+	initSubCmds(tfDocsCmd, builder.BuildTfDocsManager(conf, hclParser), tfDocsParams)
+
+	rootCmd.AddCommand(tfDocsCmd)
+
+	infracostCmd := &cobra.Command{
+		Use:   cmdconst.InfracostName,
+		Short: infracostHelp,
+		Long:  infracostHelp,
+	}
+
+	infracostParams := subCmdParams{
+		needToken: true, remoteEnvName: envname.InfracostRemoteURL, pRemote: &conf.Infracost.RemoteURL,
+	}
+	initSubCmds(infracostCmd, builder.BuildInfracostManager(conf, hclParser), infracostParams)
+
+	rootCmd.AddCommand(infracostCmd)
+
+	for _, def := range conf.Tools {
+		declaredHelp := loghelper.Concat(helpPrefix, def.DisplayName, " (https://github.com/", def.Repository, ").")
+		declaredParams := subCmdParams{
+			needToken: true, remoteEnvName: def.EnvNames().RemoteURL, pRemote: &def.Remote.RemoteURL,
+		}
+		addExtraToolCmd(rootCmd, def.Name, declaredHelp, builder.BuildDeclaredManager(conf, def), declaredParams)
+	}
+
+	for _, plugin := range conf.Plugins {
+		pluginHelp := loghelper.Concat(helpPrefix, plugin.Name, " (with plugin ", plugin.Path, ").")
+		pluginParams := subCmdParams{
+			needToken: false, remoteEnvName: plugin.EnvNames().RemoteURL, pRemote: &plugin.RemoteURL,
+		}
+		addExtraToolCmd(rootCmd, plugin.Name, pluginHelp, builder.BuildPluginManager(conf, plugin), pluginParams)
+	}
+
 	return rootCmd
 }
 
+// add subcommand of a declared tool or a plugin (their names can not conflict with existing commands, see reservedNames).
+func addExtraToolCmd(rootCmd *cobra.Command, toolName string, help string, versionManager versionmanager.VersionManager, params subCmdParams) {
+	toolCmd := &cobra.Command{
+		Use:   toolName,
+		Short: help,
+		Long:  help,
+	}
+	initSubCmds(toolCmd, versionManager, params)
+
+	rootCmd.AddCommand(toolCmd)
+}
+
+// return names of registered tools and of root commands (with their aliases),
+// they can not be used by declared tools or plugins.
+func reservedNames(conf *config.Config, hclParser *hclparse.Parser) []string {
+	probeConf := *conf // the probe command is discarded, its flags must not be bound to conf
+	probeConf.Plugins, probeConf.Tools = nil, nil
+	rootCmd := initRootCmd(&probeConf, hclParser)
+	rootCmd.InitDefaultHelpCmd()
+	rootCmd.InitDefaultCompletionCmd()
+
+	names := append(slices.Collect(maps.Keys(builder.Builders)), cmdconst.CallSubCmd)
+	for _, cmd := range rootCmd.Commands() {
+		names = append(names, cmd.Name())
+		names = append(names, cmd.Aliases...)
+	}
+
+	return names
+}
+
 func manageNoArgsCmd(conf *config.Config, hclParser *hclparse.Parser) {
 	if len(os.Args) > 1 {
 		return
@@ -254,12 +361,14 @@ func newUpdatePathCmd(gha bool) *cobra.Command {
 func initSubCmds(cmd *cobra.Command, versionManager versionmanager.VersionManager, params subCmdParams) {
 	cmd.AddCommand(newConstraintCmd(versionManager))
 	cmd.AddCommand(newDetectCmd(versionManager, params))
+	cmd.AddCommand(newInfoCmd(versionManager))
 	cmd.AddCommand(newInstallCmd(versionManager, params))
 	cmd.AddCommand(newListCmd(versionManager))
 	cmd.AddCommand(newListRemoteCmd(versionManager, params))
 	cmd.AddCommand(newResetCmd(versionManager))
 	cmd.AddCommand(newUninstallCmd(versionManager))
 	cmd.AddCommand(newUseCmd(versionManager, params))
+	cmd.AddCommand(newToolVerifyCmd(versionManager))
 }
 
 func initAgnosticProxySet(conf *config.Config) map[string]struct{} {
@@ -0,0 +1,83 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+func TestReservedNames(t *testing.T) {
+	t.Parallel()
+
+	names := reservedNames(newTestConf(t), hclparse.NewParser())
+	for _, name := range []string{"cache", "completion", "help", "install-all", "terraform", "tf", "verify", "version"} {
+		if !slices.Contains(names, name) {
+			t.Error("Unmatching results, missing", name, "in", names)
+		}
+	}
+}
+
+func TestToolNamedLikeCommand(t *testing.T) {
+	t.Parallel()
+
+	conf := newTestConf(t)
+	toolsConf := "cache:\n  repository: example/cache\n  asset: cache.zip\n"
+	if err := os.WriteFile(filepath.Join(conf.RootPath, "tools.yaml"), []byte(toolsConf), 0o600); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err := conf.InitToolDefs(reservedNames(conf, hclparse.NewParser())); !errors.Is(err, config.ErrToolDefinition) {
+		t.Error("Should fail on tool named like a command, get :", err)
+	}
+
+	if runtime.GOOS == "windows" {
+		return // plugin detection relies on file mode
+	}
+
+	if err := os.MkdirAll(conf.PluginsPath(), 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(conf.PluginsPath(), config.PluginPrefix+"lock"), []byte("#!/bin/sh\n"), 0o755); err != nil { //nolint
+		t.Fatal("Unexpected error :", err)
+	}
+
+	conf.InitPlugins(reservedNames(conf, hclparse.NewParser()))
+	if len(conf.Plugins) != 0 {
+		t.Error("Should skip plugin named like a command, get :", conf.Plugins)
+	}
+}
+
+func newTestConf(t *testing.T) *config.Config {
+	t.Helper()
+
+	rootPath := t.TempDir()
+
+	return &config.Config{Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv, RootPath: rootPath, WorkPath: rootPath}
+}
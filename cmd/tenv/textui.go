@@ -44,7 +44,7 @@ const (
 	selectedColorCode = "170"
 )
 
-var tools = []list.Item{item(cmdconst.TofuName), item(cmdconst.TerraformName), item(cmdconst.TerragruntName), item(cmdconst.TerramateName), item(cmdconst.AtmosName)} //nolint
+var tools = []list.Item{item(cmdconst.TofuName), item(cmdconst.TerraformName), item(cmdconst.TerragruntName), item(cmdconst.TerramateName), item(cmdconst.AtmosName), item(cmdconst.TflintName), item(cmdconst.TerraformDocsName), item(cmdconst.InfracostName)} //nolint
 
 var (
 	helpStyle         = list.DefaultStyles().HelpStyle                                    //nolint
@@ -178,6 +178,14 @@ func (m itemSelector) View() string {
 func toolUI(ctx context.Context, conf *config.Config, hclParser *hclparse.Parser) error {
 	conf.InitDisplayer(false)
 
+	toolItems := slices.Clone(tools)
+	for _, def := range conf.Tools {
+		toolItems = append(toolItems, item(def.Name))
+	}
+	for _, plugin := range conf.Plugins {
+		toolItems = append(toolItems, item(plugin.Name))
+	}
+
 	// shared object
 	selection := map[string]struct{}{}
 
@@ -185,7 +193,7 @@ func toolUI(ctx context.Context, conf *config.Config, hclParser *hclparse.Parser
 		choices: selection,
 	}
 
-	displayList := list.New(tools, delegate, defaultWidth, listHeight)
+	displayList := list.New(toolItems, delegate, defaultWidth, listHeight)
 	displayList.Title = "Which tool do you want to manage ?"
 	displayList.SetShowStatusBar(false)
 	displayList.SetFilteringEnabled(false)
@@ -212,7 +220,7 @@ func toolUI(ctx context.Context, conf *config.Config, hclParser *hclparse.Parser
 		return nil
 	}
 
-	for _, toolItem := range tools {
+	for _, toolItem := range toolItems {
 		tool := toolItem.FilterValue()
 		if _, selected := selection[tool]; selected {
 			if err = manageUI(ctx, builder.Builders[tool](conf, hclParser)); err != nil {
@@ -0,0 +1,177 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/versionmanager"
+	"github.com/tofuutils/tenv/v4/versionmanager/builder"
+)
+
+const (
+	verifyHelp = "Check installed files against hashes recorded at installation."
+
+	verifyDetail = `Hashes of installed files are recomputed and compared to the ones recorded in version manifest.json,
+reporting modified, missing or added files (versions installed by older tenv versions have no record and are skipped).
+
+With --upstream flag, recorded archive sha256 is also compared to the one in upstream checksums file
+(with its signature checked depending on TENV_VALIDATION), this comparison is skipped when upstream
+is not reachable (offline mode or network failure).
+
+Exit with a non zero code when a check fails.`
+)
+
+var errVerify = errors.New("verification failed")
+
+func newVerifyCmd(conf *config.Config, hclParser *hclparse.Parser) *cobra.Command {
+	toolNames := slices.Sorted(maps.Keys(builder.Builders))
+
+	var descBuilder strings.Builder
+	descBuilder.WriteString(verifyHelp)
+	descBuilder.WriteString("\n\nAll installed versions of selected tools (")
+	descBuilder.WriteString(strings.Join(toolNames, ", "))
+	descBuilder.WriteString(", all without parameter) are checked.\n\n")
+	descBuilder.WriteString(verifyDetail)
+
+	upstream := false
+
+	verifyCmd := &cobra.Command{
+		Use:          "verify [tool]...",
+		Short:        verifyHelp,
+		Long:         descBuilder.String(),
+		ValidArgs:    toolNames,
+		Args:         cobra.OnlyValidArgs,
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			conf.InitDisplayer(false)
+
+			selectedNames := toolNames
+			if len(args) != 0 {
+				selectedNames = args
+			}
+
+			ctx := context.Background()
+			failed := 0
+			for _, toolName := range selectedNames {
+				versionManager := builder.Builders[toolName](conf, hclParser)
+				count, err := verifyVersions(ctx, versionManager, nil, upstream)
+				if err != nil {
+					return err
+				}
+				failed += count
+			}
+
+			return verifyResult(failed)
+		},
+	}
+
+	addUpstreamFlag(verifyCmd.Flags(), &upstream)
+
+	return verifyCmd
+}
+
+func newToolVerifyCmd(versionManager versionmanager.VersionManager) *cobra.Command {
+	conf := versionManager.Conf
+
+	var descBuilder strings.Builder
+	descBuilder.WriteString(verifyHelp)
+	descBuilder.WriteString("\n\nWithout parameter, all installed ")
+	descBuilder.WriteString(versionManager.FolderName)
+	descBuilder.WriteString(" versions are checked.\n\n")
+	descBuilder.WriteString(verifyDetail)
+
+	upstream := false
+
+	verifyCmd := &cobra.Command{
+		Use:          "verify [version]...",
+		Short:        loghelper.Concat("Check installed ", versionManager.FolderName, " files against hashes recorded at installation."),
+		Long:         descBuilder.String(),
+		SilenceUsage: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			conf.InitDisplayer(false)
+
+			failed, err := verifyVersions(context.Background(), versionManager, args, upstream)
+			if err != nil {
+				return err
+			}
+
+			return verifyResult(failed)
+		},
+	}
+
+	addUpstreamFlag(verifyCmd.Flags(), &upstream)
+
+	return verifyCmd
+}
+
+func addUpstreamFlag(flags *pflag.FlagSet, pUpstream *bool) {
+	flags.BoolVarP(pUpstream, "upstream", "u", false, "also compare recorded archive sha256 to upstream checksums file")
+}
+
+// return the number of failed checks, versions default to all installed ones.
+func verifyVersions(ctx context.Context, versionManager versionmanager.VersionManager, versions []string, upstream bool) (int, error) {
+	if len(versions) == 0 {
+		datedVersions, err := versionManager.ListLocal(false)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, datedVersion := range datedVersions {
+			versions = append(versions, datedVersion.Version)
+		}
+	}
+
+	failed := 0
+	for _, version := range versions {
+		prefix := loghelper.Concat(versionManager.FolderName, " ", version, " : ")
+		switch err := versionManager.Verify(ctx, version, upstream); {
+		case err == nil:
+			loghelper.StdDisplay(prefix + "OK")
+		case errors.Is(err, versionmanager.ErrNoChecksum), errors.Is(err, versionmanager.ErrUpstreamUnreachable): // files already checked
+			loghelper.StdDisplay(prefix + "OK, upstream not checkable (skipped), " + err.Error())
+		case versionmanager.IsUnverifiable(err):
+			loghelper.StdDisplay(prefix + "skipped, " + err.Error())
+		default:
+			loghelper.StdDisplay(prefix + "FAILED, " + err.Error())
+			failed++
+		}
+	}
+
+	return failed, nil
+}
+
+func verifyResult(failed int) error {
+	if failed == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w for %d version(s)", errVerify, failed)
+}
@@ -20,13 +20,16 @@ package cmdconst
 
 const (
 	// tools name.
-	AgnosticName   = "tf"
-	AtmosName      = "atmos"
-	TenvName       = "tenv"
-	TerraformName  = "terraform"
-	TerragruntName = "terragrunt"
-	TerramateName  = "terramate"
-	TofuName       = "tofu"
+	AgnosticName      = "tf"
+	AtmosName         = "atmos"
+	InfracostName     = "infracost"
+	TenvName          = "tenv"
+	TerraformDocsName = "terraform-docs"
+	TerraformName     = "terraform"
+	TerragruntName    = "terragrunt"
+	TerramateName     = "terramate"
+	TflintName        = "tflint"
+	TofuName          = "tofu"
 
 	// full project name.
 	OpentofuName = "opentofu"
@@ -23,9 +23,12 @@ import (
 	"errors"
 	"fmt"
 	"io/fs"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/hashicorp/go-hclog"
@@ -34,62 +37,215 @@ import (
 	"github.com/tofuutils/tenv/v4/config/cmdconst"
 	"github.com/tofuutils/tenv/v4/config/envname"
 	configutils "github.com/tofuutils/tenv/v4/config/utils"
+	pgpcheck "github.com/tofuutils/tenv/v4/pkg/check/pgp"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	githubcache "github.com/tofuutils/tenv/v4/pkg/github/cache"
 	githuburl "github.com/tofuutils/tenv/v4/pkg/github/url"
 	"github.com/tofuutils/tenv/v4/pkg/githubapp"
 	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/pkg/tty"
 	atmosurl "github.com/tofuutils/tenv/v4/versionmanager/retriever/atmos/url"
+	infracosturl "github.com/tofuutils/tenv/v4/versionmanager/retriever/infracost/url"
 	terraformurl "github.com/tofuutils/tenv/v4/versionmanager/retriever/terraform/url"
+	terraformdocsurl "github.com/tofuutils/tenv/v4/versionmanager/retriever/terraformdocs/url"
 	terragrunturl "github.com/tofuutils/tenv/v4/versionmanager/retriever/terragrunt/url"
 	terramateurl "github.com/tofuutils/tenv/v4/versionmanager/retriever/terramate/url"
+	tflinturl "github.com/tofuutils/tenv/v4/versionmanager/retriever/tflint/url"
 	tofuurl "github.com/tofuutils/tenv/v4/versionmanager/retriever/tofu/url"
 )
 
 const (
-	defaultDirName = ".tenv"
+	cacheDirName          = "tenv"
+	defaultCacheMaxSize   = 2 << 30 // 2 GiB
+	defaultConnectTimeout = 30 * time.Second
+	defaultDirName        = ".tenv"
+	defaultGithubCacheTTL = 10 * time.Minute
+	defaultInstallConc    = 4
+	githubCacheDirName    = "github-cache"
+	keysDirName           = "keys"
+	providersDirName      = "providers"
+	sigstoreCacheDirName  = "sigstore-tuf"
+	validationName        = "validation"
+	defaultReadTimeout    = time.Minute
+)
+
+const (
+	failMissingSigName = "fail"
+	warnMissingSigName = "warn"
+)
+
+const (
+	WarnMissingSig MissingSigPolicy = iota
+	FailMissingSig
+)
+
+// MissingSigPolicy tells what to do when signature validation is requested but a version has no published signature.
+type MissingSigPolicy uint8
+
+func ParseMissingSigPolicy(policy string) MissingSigPolicy {
+	if policy == failMissingSigName {
+		return FailMissingSig
+	}
+
+	return WarnMissingSig
+}
+
+func (policy MissingSigPolicy) String() string {
+	if policy == FailMissingSig {
+		return failMissingSigName
+	}
+
+	return warnMissingSigName
+}
+
+const (
+	attestationValidationName = "attestation"
+	noValidationName          = "none"
+	shaValidationName         = "sha"
+	signNoTlogValidationName  = "sign-no-tlog"
+	signValidationName        = "sign"
+	strictValidationName      = "strict"
+	unknownValidationName     = "unknown"
+)
+
+var (
+	ErrStrictSkip          = errors.New("skipping checks is not allowed with strict validation")
+	ErrValidationDowngrade = errors.New("strict validation refuses weaker checks than requested")
 )
 
 const (
 	SignValidation ValidationMode = iota
 	ShaValidation
 	NoValidation
+	AttestationValidation // sha256 checksum and GitHub build provenance attestation (instead of signature)
+	SignNoTlogValidation  // signature checked without transparency log inclusion (only applied, never requested)
+	UnknownValidation     // applied checks are not known (decoded from an empty or unrecognized value), weaker than none
 )
 
 type ValidationMode uint8
 
 func ParseValidationMode(mode string) ValidationMode {
 	switch mode {
-	case "none":
+	case attestationValidationName:
+		return AttestationValidation
+	case noValidationName:
 		return NoValidation
-	case "sha":
+	case shaValidationName:
 		return ShaValidation
 	default:
 		return SignValidation
 	}
 }
 
+// ParseValidation is like ParseValidationMode and reports strict validation (signature mode without silent downgrade).
+func ParseValidation(value string) (ValidationMode, bool) {
+	if value == strictValidationName {
+		return SignValidation, true
+	}
+
+	return ParseValidationMode(value), false
+}
+
+func (mode ValidationMode) MarshalText() ([]byte, error) {
+	return []byte(mode.String()), nil
+}
+
+// strength ranks modes from the weakest to the strongest (declaration order differs : attestation was added last).
+func (mode ValidationMode) strength() int {
+	switch mode {
+	case AttestationValidation:
+		return 4
+	case SignValidation:
+		return 3
+	case SignNoTlogValidation:
+		return 2
+	case ShaValidation:
+		return 1
+	case NoValidation:
+		return 0
+	default:
+		return -1
+	}
+}
+
+func (mode ValidationMode) String() string {
+	switch mode {
+	case AttestationValidation:
+		return attestationValidationName
+	case NoValidation:
+		return noValidationName
+	case ShaValidation:
+		return shaValidationName
+	case SignNoTlogValidation:
+		return signNoTlogValidationName
+	case UnknownValidation:
+		return unknownValidationName
+	default:
+		return signValidationName
+	}
+}
+
+// UnmarshalText only decodes exact mode names, unlike ParseValidationMode it never defaults to SignValidation.
+func (mode *ValidationMode) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case attestationValidationName:
+		*mode = AttestationValidation
+	case noValidationName:
+		*mode = NoValidation
+	case shaValidationName:
+		*mode = ShaValidation
+	case signNoTlogValidationName:
+		*mode = SignNoTlogValidation
+	case signValidationName:
+		*mode = SignValidation
+	default:
+		*mode = UnknownValidation
+	}
+
+	return nil
+}
+
 type Config struct {
 	Arch             string
 	Atmos            RemoteConfig
+	CacheMaxSize     int64
+	CachePath        string
 	Displayer        loghelper.Displayer
 	DisplayVerbose   bool
 	ForceQuiet       bool
 	ForceRemote      bool
 	Getenv           configutils.GetenvFunc
 	GithubActions    bool
+	GithubCacheTTL   time.Duration // negative value disable GitHub API cache
 	GithubToken      string
+	HTTPClient       *http.Client // nil means http.DefaultClient
+	Infracost        RemoteConfig
+	InstallConc      int // maximum number of parallel installations (at least one)
 	LockPath         string
+	MissingSig       MissingSigPolicy
+	Offline          bool
+	Plugins          []*Plugin // external retrievers (see InitPlugins)
 	remoteConfLoaded bool
 	RemoteConfPath   string
 	RootPath         string
+	SigstoreRoot     string // local Sigstore trusted root (fetched through Sigstore TUF repository when empty)
 	SkipInstall      bool
+	skipSign         bool
+	skipSum          bool
+	StrictValidation bool   // refuse installation when Validation can not really be applied
+	TenvVersion      string // recorded in installed version manifest (build information are used when empty)
 	Tf               RemoteConfig
+	TfDocs           RemoteConfig
 	TfKeyPathOrURL   string
+	Tflint           RemoteConfig
 	Tg               RemoteConfig
 	Tm               RemoteConfig
 	Tofu             RemoteConfig
 	TofuKeyPathOrURL string
+	Tools            []*ToolDefinition // declared in tools.yaml (see InitToolDefs)
+	ToolsConfPath    string
 	UserPath         string
-	Validation       ValidationMode
+	Validation       ValidationMode // can be overridden per tool (see GetValidation)
 	WorkPath         string
 }
 
@@ -104,12 +260,19 @@ func DefaultConfig() (Config, error) {
 	return Config{
 		Arch:             runtime.GOARCH,
 		Atmos:            makeDefaultRemoteConfig(atmosurl.Github, githuburl.Base),
+		CacheMaxSize:     defaultCacheMaxSize,
+		CachePath:        defaultCachePath(tenvPath),
 		Getenv:           EmptyGetenv,
+		GithubCacheTTL:   defaultGithubCacheTTL,
+		Infracost:        makeDefaultRemoteConfig(infracosturl.Github, githuburl.Base),
+		InstallConc:      defaultInstallConc,
 		LockPath:         tenvPath,
 		remoteConfLoaded: true,
 		RootPath:         tenvPath,
 		SkipInstall:      true,
 		Tf:               makeDefaultRemoteConfig(terraformurl.Hashicorp, terraformurl.Hashicorp),
+		TfDocs:           makeDefaultRemoteConfig(terraformdocsurl.Github, githuburl.Base),
+		Tflint:           makeDefaultRemoteConfig(tflinturl.Github, githuburl.Base),
 		Tg:               makeDefaultRemoteConfig(terragrunturl.Github, githuburl.Base),
 		Tm:               makeDefaultRemoteConfig(terramateurl.Github, githuburl.Base),
 		Tofu:             makeDefaultRemoteConfig(tofuurl.Github, githuburl.Base),
@@ -154,16 +317,63 @@ func InitConfigFromEnv() (Config, error) {
 		lockPath = rootPath // Default to root path for backward compatibility
 	}
 
+	offline, err := getenv.Bool(false, envname.TenvOffline)
+	if err != nil {
+		return Config{}, err
+	}
+
 	quiet, err := getenv.Bool(false, envname.TenvQuiet)
 	if err != nil {
 		return Config{}, err
 	}
 
+	skipCache, err := getenv.Bool(false, envname.TenvSkipCache)
+	if err != nil {
+		return Config{}, err
+	}
+
+	cachePath := ""
+	if !skipCache {
+		cachePath = getenv(envname.TenvCacheDir)
+		if cachePath == "" {
+			cachePath = defaultCachePath(rootPath)
+		}
+	}
+
+	cacheMaxSize := int64(defaultCacheMaxSize)
+	if cacheMaxSizeStr := getenv(envname.TenvCacheMax); cacheMaxSizeStr != "" {
+		if cacheMaxSize, err = configutils.ParseSize(cacheMaxSizeStr); err != nil {
+			return Config{}, fmt.Errorf("invalid %s value : %w", envname.TenvCacheMax, err)
+		}
+	}
+
+	githubCacheTTL := time.Duration(-1)
+	if !skipCache {
+		if githubCacheTTL, err = parseDuration(getenv, envname.TenvGithubCacheTTL, defaultGithubCacheTTL); err != nil {
+			return Config{}, err
+		}
+	}
+
+	httpClient, err := initHTTPClient(getenv)
+	if err != nil {
+		return Config{}, err
+	}
+
+	installConc := defaultInstallConc
+	if installConcStr := getenv(envname.TenvInstallConc); installConcStr != "" {
+		if installConc, err = strconv.Atoi(installConcStr); err != nil {
+			return Config{}, fmt.Errorf("invalid %s value : %w", envname.TenvInstallConc, err)
+		}
+	}
+
+	validation, strictValidation := ParseValidation(getenv(envname.TenvValidation))
+
 	githubToken := getenv.Fallback(envname.TenvToken, envname.TofuToken)
-	if githubToken == "" {
+	if githubToken == "" && !offline { // GitHub App authentication need network access
 		if appID := getenv(envname.TenvGithubAppID); appID != "" {
 			githubToken, err = githubapp.InstallationToken(
 				context.Background(),
+				httpClient,
 				appID,
 				getenv(envname.TenvGithubAppInstallationID),
 				getenv(envname.TenvGithubAppPEM),
@@ -177,28 +387,93 @@ func InitConfigFromEnv() (Config, error) {
 
 	return Config{
 		Arch:             arch,
-		Atmos:            makeRemoteConfig(getenv, envname.AtmosRemoteURL, envname.AtmosListURL, envname.AtmosInstallMode, envname.AtmosListMode, atmosurl.Github, githuburl.Base),
+		Atmos:            makeRemoteConfig(getenv, envname.AtmosRemoteURL, envname.AtmosListURL, envname.AtmosInstallMode, envname.AtmosListMode, envname.AtmosValidation, atmosurl.Github, githuburl.Base),
+		CacheMaxSize:     cacheMaxSize,
+		CachePath:        cachePath,
 		ForceQuiet:       quiet,
 		ForceRemote:      forceRemote,
 		Getenv:           getenv,
 		GithubActions:    getenv.Present(envname.GithubActions),
+		GithubCacheTTL:   githubCacheTTL,
 		GithubToken:      githubToken,
+		HTTPClient:       httpClient,
+		Infracost:        makeRemoteConfig(getenv, envname.InfracostRemoteURL, envname.InfracostListURL, envname.InfracostInstallMode, envname.InfracostListMode, envname.InfracostValidation, infracosturl.Github, githuburl.Base),
+		InstallConc:      installConc,
 		LockPath:         lockPath,
+		MissingSig:       ParseMissingSigPolicy(getenv(envname.TenvMissingSig)),
+		Offline:          offline,
 		RemoteConfPath:   getenv(envname.TenvRemoteConf),
 		RootPath:         rootPath,
+		SigstoreRoot:     getenv(envname.TenvSigstoreRoot),
 		SkipInstall:      !autoInstall,
-		Tf:               makeRemoteConfig(getenv, envname.TfRemoteURL, envname.TfListURL, envname.TfInstallMode, envname.TfListMode, terraformurl.Hashicorp, terraformurl.Hashicorp),
+		StrictValidation: strictValidation,
+		Tf:               makeRemoteConfig(getenv, envname.TfRemoteURL, envname.TfListURL, envname.TfInstallMode, envname.TfListMode, envname.TfValidation, terraformurl.Hashicorp, terraformurl.Hashicorp),
+		TfDocs:           makeRemoteConfig(getenv, envname.TfDocsRemoteURL, envname.TfDocsListURL, envname.TfDocsInstallMode, envname.TfDocsListMode, envname.TfDocsValidation, terraformdocsurl.Github, githuburl.Base),
 		TfKeyPathOrURL:   getenv.WithDefault(terraformurl.PublicKey, envname.TfHashicorpPGPKey),
-		Tg:               makeRemoteConfig(getenv, envname.TgRemoteURL, envname.TgListURL, envname.TgInstallMode, envname.TgListMode, terragrunturl.Github, githuburl.Base),
-		Tm:               makeRemoteConfig(getenv, envname.TmRemoteURL, envname.TmListURL, envname.TmInstallMode, envname.TmListMode, terramateurl.Github, githuburl.Base),
-		Tofu:             makeRemoteConfig(getenv, envname.TofuRemoteURL, envname.TofuListURL, envname.TofuInstallMode, envname.TofuListMode, tofuurl.Github, githuburl.Base),
+		Tflint:           makeRemoteConfig(getenv, envname.TflintRemoteURL, envname.TflintListURL, envname.TflintInstallMode, envname.TflintListMode, envname.TflintValidation, tflinturl.Github, githuburl.Base),
+		Tg:               makeRemoteConfig(getenv, envname.TgRemoteURL, envname.TgListURL, envname.TgInstallMode, envname.TgListMode, envname.TgValidation, terragrunturl.Github, githuburl.Base),
+		Tm:               makeRemoteConfig(getenv, envname.TmRemoteURL, envname.TmListURL, envname.TmInstallMode, envname.TmListMode, envname.TmValidation, terramateurl.Github, githuburl.Base),
+		Tofu:             makeRemoteConfig(getenv, envname.TofuRemoteURL, envname.TofuListURL, envname.TofuInstallMode, envname.TofuListMode, envname.TofuValidation, tofuurl.Github, githuburl.Base),
 		TofuKeyPathOrURL: getenv.WithDefault(tofuurl.PublicKey, envname.TofuOpenTofuPGPKey),
+		ToolsConfPath:    getenv(envname.TenvToolsConf),
 		UserPath:         userPath,
-		Validation:       ParseValidationMode(getenv(envname.TenvValidation)),
+		Validation:       validation,
 		WorkPath:         ".",
 	}, nil
 }
 
+// DownloadContext returns a context making downloads use the shared HTTP client and the retry policy of remote
+// (or refuse network access in offline mode).
+func (conf *Config) DownloadContext(ctx context.Context, remote RemoteConfig) (context.Context, error) {
+	policy, err := remote.GetRetryPolicy()
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = download.WithRetryPolicy(ctx, policy)
+	if conf.Offline {
+		ctx = download.WithOffline(ctx)
+	}
+	if conf.HTTPClient != nil {
+		ctx = download.WithClient(ctx, conf.HTTPClient)
+	}
+
+	return ctx, nil
+}
+
+// Return nil when download cache is disabled (nil *download.Cache is usable and do not cache anything).
+func (conf *Config) DownloadCache() *download.Cache {
+	if conf.CachePath == "" {
+		return nil
+	}
+
+	return download.NewCache(conf.CachePath, conf.CacheMaxSize)
+}
+
+// PGPKeyStore returns the trust store of PGP public keys used to check toolName signatures.
+func (conf *Config) PGPKeyStore(toolName string) pgpcheck.Store {
+	return pgpcheck.NewStore(filepath.Join(conf.RootPath, keysDirName, toolName))
+}
+
+// ProvidersCachePath returns the directory filled by "tenv providers mirror" (usable as TF_PLUGIN_CACHE_DIR).
+func (conf *Config) ProvidersCachePath() string {
+	return filepath.Join(conf.RootPath, providersDirName)
+}
+
+// SigstoreCachePath returns the directory where Sigstore TUF metadata are cached.
+func (conf *Config) SigstoreCachePath() string {
+	return filepath.Join(conf.RootPath, sigstoreCacheDirName)
+}
+
+// Return nil when GitHub API cache is disabled (nil *githubcache.Cache is usable and do not cache anything).
+func (conf *Config) GithubCache() *githubcache.Cache {
+	if conf.GithubCacheTTL < 0 {
+		return nil
+	}
+
+	return githubcache.NewCache(filepath.Join(conf.RootPath, githubCacheDirName), conf.GithubCacheTTL)
+}
+
 func (conf *Config) InitDisplayer(proxyCall bool) {
 	if conf.ForceQuiet {
 		conf.Displayer = loghelper.InertDisplayer
@@ -219,12 +494,49 @@ func (conf *Config) InitDisplayer(proxyCall bool) {
 			display := loghelper.BuildDisplayFunc(os.Stderr, color.New(color.FgGreen))
 			conf.Displayer = loghelper.NewRecordingDisplayer(loghelper.MakeBasicDisplayer(appLogger, display))
 		} else {
-			conf.Displayer = loghelper.MakeBasicDisplayer(appLogger, loghelper.StdDisplay)
+			displayer := loghelper.MakeBasicDisplayer(appLogger, loghelper.StdDisplay)
+			conf.Displayer = loghelper.WithProgressRendering(displayer, os.Stdout, tty.Detect())
 		}
 	}
 }
 
+// CheckValidation returns an error when strict validation is requested for remote tool and applied mode is weaker than requested one.
+func (conf *Config) CheckValidation(remote RemoteConfig, applied ValidationMode) error {
+	mode, strict, err := conf.GetValidation(remote)
+	if err != nil || !strict || applied.strength() >= mode.strength() {
+		return err
+	}
+
+	return fmt.Errorf("%w (%s requested, %s applied)", ErrValidationDowngrade, mode, applied)
+}
+
+// GetValidation returns the validation mode for remote tool and its strictness :
+// per tool value (from env or remote configuration file) overrides global one,
+// skip flags weaken the result (refused with strict validation).
+func (conf *Config) GetValidation(remote RemoteConfig) (ValidationMode, bool, error) {
+	mode, strict := conf.Validation, conf.StrictValidation
+	if value := remote.getValueForcedDefault(validationName, remote.validation, ""); value != "" {
+		mode, strict = ParseValidation(value)
+	}
+
+	if !conf.skipSum && !conf.skipSign {
+		return mode, strict, nil
+	}
+
+	if strict {
+		return 0, false, ErrStrictSkip
+	}
+
+	if conf.skipSum || mode == NoValidation {
+		return NoValidation, false, nil
+	}
+
+	return ShaValidation, false, nil
+}
+
 func (conf *Config) InitValidation(skipSum bool, skipSign bool) {
+	conf.skipSign, conf.skipSum = skipSign, skipSum
+
 	switch {
 	case skipSum: // higher priority to --skip-sha
 		conf.Validation = NoValidation
@@ -270,12 +582,70 @@ func (conf *Config) InitRemoteConf() error {
 
 	conf.Tf.Data = remoteConf[cmdconst.TerraformName]
 	conf.Tg.Data = remoteConf[cmdconst.TerragruntName]
+	conf.Tm.Data = remoteConf[cmdconst.TerramateName]
 	conf.Tofu.Data = remoteConf[cmdconst.TofuName]
 	conf.Atmos.Data = remoteConf[cmdconst.AtmosName]
+	conf.Tflint.Data = remoteConf[cmdconst.TflintName]
+	conf.TfDocs.Data = remoteConf[cmdconst.TerraformDocsName]
+	conf.Infracost.Data = remoteConf[cmdconst.InfracostName]
+	for _, def := range conf.Tools {
+		def.Remote.Data = remoteConf[def.Name]
+	}
 
 	return nil
 }
 
+func initHTTPClient(getenv configutils.GetenvFunc) (*http.Client, error) {
+	connectTimeout, err := parseDuration(getenv, envname.TenvHTTPConnTimeout, defaultConnectTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	readTimeout, err := parseDuration(getenv, envname.TenvHTTPReadTimeout, defaultReadTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := download.NewClient(download.ClientConfig{
+		CAFile:         getenv(envname.TenvHTTPCAFile),
+		CertFile:       getenv(envname.TenvHTTPClientCert),
+		ConnectTimeout: connectTimeout,
+		KeyFile:        getenv(envname.TenvHTTPClientKey),
+		ProxyURL:       getenv(envname.TenvHTTPProxy),
+		ReadTimeout:    readTimeout,
+		UserAgent:      getenv(envname.TenvUserAgent),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("HTTP client configuration : %w", err)
+	}
+
+	return httpClient, nil
+}
+
+func parseDuration(getenv configutils.GetenvFunc, envName string, defaultValue time.Duration) (time.Duration, error) {
+	durationStr := getenv(envName)
+	if durationStr == "" {
+		return defaultValue, nil
+	}
+
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s value : %w", envName, err)
+	}
+
+	return duration, nil
+}
+
+// shared between root paths, use user cache directory when available.
+func defaultCachePath(rootPath string) string {
+	userCachePath, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(rootPath, "cache")
+	}
+
+	return filepath.Join(userCachePath, cacheDirName)
+}
+
 func EmptyGetenv(_ string) string {
 	return ""
 }
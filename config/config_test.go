@@ -0,0 +1,151 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+)
+
+func TestGetValidation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		skipSign   bool
+		skipSum    bool
+		strict     bool
+		toolValue  string
+		validation config.ValidationMode
+		wantErr    error
+		wantMode   config.ValidationMode
+		wantStrict bool
+	}{
+		{name: "Global", validation: config.ShaValidation, wantMode: config.ShaValidation},
+		{name: "GlobalStrict", strict: true, wantMode: config.SignValidation, wantStrict: true},
+		{name: "ToolOverride", strict: true, toolValue: "sha", wantMode: config.ShaValidation},
+		{name: "ToolStrict", validation: config.NoValidation, toolValue: "strict", wantMode: config.SignValidation, wantStrict: true},
+		{name: "SkipSign", skipSign: true, wantMode: config.ShaValidation},
+		{name: "SkipSum", skipSign: true, skipSum: true, wantMode: config.NoValidation},
+		{name: "ToolAttestation", toolValue: "attestation", wantMode: config.AttestationValidation},
+		{name: "SkipSignAttestation", skipSign: true, validation: config.AttestationValidation, wantMode: config.ShaValidation},
+		{name: "SkipSignStrict", skipSign: true, strict: true, wantErr: config.ErrStrictSkip},
+		{name: "SkipSumToolStrict", skipSum: true, toolValue: "strict", wantErr: config.ErrStrictSkip},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := config.Config{StrictValidation: test.strict, Validation: test.validation}
+			conf.InitValidation(test.skipSum, test.skipSign)
+			remote := config.RemoteConfig{Data: map[string]string{"validation": test.toolValue}}
+
+			mode, strict, err := conf.GetValidation(remote)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatal("Unexpected error, expected", test.wantErr, "get :", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if mode != test.wantMode || strict != test.wantStrict {
+				t.Error("Unmatching results, expected", test.wantMode, test.wantStrict, "get", mode, strict)
+			}
+		})
+	}
+}
+
+func TestCheckValidation(t *testing.T) {
+	t.Parallel()
+
+	conf := config.Config{StrictValidation: true}
+	if err := conf.CheckValidation(config.RemoteConfig{}, config.SignValidation); err != nil {
+		t.Error("Unexpected error :", err)
+	}
+
+	if err := conf.CheckValidation(config.RemoteConfig{}, config.ShaValidation); !errors.Is(err, config.ErrValidationDowngrade) {
+		t.Error("Should fail on downgraded validation, get :", err)
+	}
+
+	if err := conf.CheckValidation(config.RemoteConfig{}, config.NoValidation); !errors.Is(err, config.ErrValidationDowngrade) {
+		t.Error("Should fail on downgraded validation, get :", err)
+	}
+
+	// attestation is stronger than signature
+	if err := conf.CheckValidation(config.RemoteConfig{}, config.AttestationValidation); err != nil {
+		t.Error("Unexpected error :", err)
+	}
+
+	conf.Validation = config.AttestationValidation
+	for _, applied := range []config.ValidationMode{config.SignValidation, config.ShaValidation, config.NoValidation} {
+		if err := conf.CheckValidation(config.RemoteConfig{}, applied); !errors.Is(err, config.ErrValidationDowngrade) {
+			t.Error("Should fail on downgraded validation from attestation to", applied, ", get :", err)
+		}
+	}
+
+	conf.Validation = config.SignValidation
+	if err := conf.CheckValidation(config.RemoteConfig{}, config.SignNoTlogValidation); !errors.Is(err, config.ErrValidationDowngrade) {
+		t.Error("Should fail on signature check without transparency log, get :", err)
+	}
+
+	conf.StrictValidation = false
+	if err := conf.CheckValidation(config.RemoteConfig{}, config.ShaValidation); err != nil {
+		t.Error("Unexpected error :", err)
+	}
+}
+
+func TestValidationModeUnmarshalText(t *testing.T) {
+	t.Parallel()
+
+	modes := []config.ValidationMode{
+		config.AttestationValidation, config.NoValidation, config.ShaValidation, config.SignNoTlogValidation, config.SignValidation, config.UnknownValidation,
+	}
+	for _, mode := range modes {
+		text, err := mode.MarshalText()
+		if err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+
+		var decoded config.ValidationMode
+		if err = decoded.UnmarshalText(text); err != nil || decoded != mode {
+			t.Error("Unmatching results, expected", mode, "get", decoded, err)
+		}
+	}
+
+	for _, text := range []string{"", "strict", "signed"} {
+		var decoded config.ValidationMode
+		if err := decoded.UnmarshalText([]byte(text)); err != nil || decoded != config.UnknownValidation {
+			t.Error("Should decode", text, "as unknown, get :", decoded, err)
+		}
+	}
+
+	// unknown is weaker than none
+	conf := config.Config{StrictValidation: true, Validation: config.NoValidation}
+	if err := conf.CheckValidation(config.RemoteConfig{}, config.UnknownValidation); !errors.Is(err, config.ErrValidationDowngrade) {
+		t.Error("Should fail on unknown validation, get :", err)
+	}
+}
@@ -18,6 +18,8 @@
 
 package envname
 
+import "strings"
+
 const (
 	agnosticProxy           = "AGNOSTIC_PROXY"
 	arch                    = "ARCH"
@@ -34,6 +36,7 @@ const (
 	remoteURL               = "REMOTE"
 	remoteUser              = "REMOTE_USER"
 	rootPath                = "ROOT"
+	validation              = "VALIDATION"
 	VersionSuffix           = "VERSION"
 
 	githubPrefix  = "GITHUB_"
@@ -41,10 +44,13 @@ const (
 	GithubOutput  = githubPrefix + "OUTPUT"
 	token         = githubPrefix + "TOKEN"
 
+	httpPrefix = "HTTP_"
+
 	TenvGithubAppID             = tenvPrefix + githubPrefix + "APP_ID"
 	TenvGithubAppInstallationID = tenvPrefix + githubPrefix + "APP_INSTALLATION_ID"
 	TenvGithubAppPEM            = tenvPrefix + githubPrefix + "APP_PEM"
 	TenvGithubAppPEMFile        = tenvPrefix + githubPrefix + "APP_PEM_FILE"
+	TenvGithubCacheTTL          = tenvPrefix + githubPrefix + "CACHE_TTL"
 
 	AtmosPrefix      = "ATMOS_"
 	AtmosInstallMode = AtmosPrefix + installMode
@@ -53,19 +59,58 @@ const (
 	AtmosRemotePass  = AtmosPrefix + remotePass
 	AtmosRemoteURL   = AtmosPrefix + remoteURL
 	AtmosRemoteUser  = AtmosPrefix + remoteUser
+	AtmosValidation  = AtmosPrefix + validation
+
+	InfracostPrefix      = "INFRACOST_"
+	InfracostInstallMode = InfracostPrefix + installMode
+	InfracostListMode    = InfracostPrefix + listMode
+	InfracostListURL     = InfracostPrefix + listURL
+	InfracostRemotePass  = InfracostPrefix + remotePass
+	InfracostRemoteURL   = InfracostPrefix + remoteURL
+	InfracostRemoteUser  = InfracostPrefix + remoteUser
+	InfracostValidation  = InfracostPrefix + validation
+
+	tenvPrefix           = "TENV_"
+	TenvArch             = tenvPrefix + arch
+	TenvAutoInstall      = tenvPrefix + autoInstall
+	TenvCacheDir         = tenvPrefix + "CACHE_DIR"
+	TenvCacheMax         = tenvPrefix + "CACHE_MAX_SIZE"
+	TenvForceRemote      = tenvPrefix + forceRemote
+	TenvHTTPCAFile       = tenvPrefix + httpPrefix + "CA_FILE"
+	TenvHTTPClientCert   = tenvPrefix + httpPrefix + "CLIENT_CERT"
+	TenvHTTPClientKey    = tenvPrefix + httpPrefix + "CLIENT_KEY"
+	TenvHTTPConnTimeout  = tenvPrefix + httpPrefix + "CONNECT_TIMEOUT"
+	TenvHTTPProxy        = tenvPrefix + httpPrefix + "PROXY"
+	TenvHTTPReadTimeout  = tenvPrefix + httpPrefix + "READ_TIMEOUT"
+	TenvInstallConc      = tenvPrefix + "INSTALL_CONCURRENCY"
+	TenvLog              = tenvPrefix + log
+	TenvMissingSig       = tenvPrefix + "MISSING_SIGNATURE"
+	TenvOffline          = tenvPrefix + "OFFLINE"
+	TenvQuiet            = tenvPrefix + quiet
+	TenvRateLimitWait    = tenvPrefix + "RATE_LIMIT_MAX_WAIT"
+	TenvRemoteConf       = tenvPrefix + "REMOTE_CONF"
+	TenvRetryAttempts    = tenvPrefix + "RETRY_ATTEMPTS"
+	TenvRetryBackoff     = tenvPrefix + "RETRY_BACKOFF"
+	TenvRetryMaxBackoff  = tenvPrefix + "RETRY_MAX_BACKOFF"
+	TenvRetryStatusCodes = tenvPrefix + "RETRY_STATUS_CODES"
+	TenvRootPath         = tenvPrefix + rootPath
+	TenvSigstoreRoot     = tenvPrefix + "SIGSTORE_TRUSTED_ROOT"
+	TenvLockPath         = tenvPrefix + "LOCK_PATH"
+	TenvSkipCache        = tenvPrefix + "SKIP_CACHE"
+	TenvSkipLastUse      = tenvPrefix + "SKIP_LAST_USE"
+	TenvToken            = tenvPrefix + token
+	TenvToolsConf        = tenvPrefix + "TOOLS_CONF"
+	TenvUserAgent        = tenvPrefix + "USER_AGENT"
+	TenvValidation       = tenvPrefix + validation
 
-	tenvPrefix      = "TENV_"
-	TenvArch        = tenvPrefix + arch
-	TenvAutoInstall = tenvPrefix + autoInstall
-	TenvForceRemote = tenvPrefix + forceRemote
-	TenvLog         = tenvPrefix + log
-	TenvQuiet       = tenvPrefix + quiet
-	TenvRemoteConf  = tenvPrefix + "REMOTE_CONF"
-	TenvRootPath    = tenvPrefix + rootPath
-	TenvLockPath    = tenvPrefix + "LOCK_PATH"
-	TenvSkipLastUse = tenvPrefix + "SKIP_LAST_USE"
-	TenvToken       = tenvPrefix + token
-	TenvValidation  = tenvPrefix + "VALIDATION"
+	TfDocsPrefix      = "TERRAFORM_DOCS_"
+	TfDocsInstallMode = TfDocsPrefix + installMode
+	TfDocsListMode    = TfDocsPrefix + listMode
+	TfDocsListURL     = TfDocsPrefix + listURL
+	TfDocsRemotePass  = TfDocsPrefix + remotePass
+	TfDocsRemoteURL   = TfDocsPrefix + remoteURL
+	TfDocsRemoteUser  = TfDocsPrefix + remoteUser
+	TfDocsValidation  = TfDocsPrefix + validation
 
 	TfenvPrefix          = "TFENV_"
 	TfenvTerraformPrefix = TfenvPrefix + "TERRAFORM_"
@@ -81,6 +126,7 @@ const (
 	TfRemoteURL          = TfenvPrefix + remoteURL
 	TfRemoteUser         = TfenvPrefix + remoteUser
 	TfRootPath           = TfenvPrefix + rootPath
+	TfValidation         = TfenvPrefix + validation
 
 	TgPrefix      = "TG_"
 	TgInstallMode = TgPrefix + installMode
@@ -89,6 +135,7 @@ const (
 	TgRemotePass  = TgPrefix + remotePass
 	TgRemoteURL   = TgPrefix + remoteURL
 	TgRemoteUser  = TgPrefix + remoteUser
+	TgValidation  = TgPrefix + validation
 
 	TmPrefix      = "TM_"
 	TmInstallMode = TmPrefix + installMode
@@ -97,6 +144,16 @@ const (
 	TmRemotePass  = TmPrefix + remotePass
 	TmRemoteURL   = TmPrefix + remoteURL
 	TmRemoteUser  = TmPrefix + remoteUser
+	TmValidation  = TmPrefix + validation
+
+	TflintPrefix      = "TFLINT_"
+	TflintInstallMode = TflintPrefix + installMode
+	TflintListMode    = TflintPrefix + listMode
+	TflintListURL     = TflintPrefix + listURL
+	TflintRemotePass  = TflintPrefix + remotePass
+	TflintRemoteURL   = TflintPrefix + remoteURL
+	TflintRemoteUser  = TflintPrefix + remoteUser
+	TflintValidation  = TflintPrefix + validation
 
 	TofuenvPrefix      = "TOFUENV_"
 	TofuenvTofuPrefix  = TofuenvPrefix + "TOFU_"
@@ -114,4 +171,26 @@ const (
 	TofuRootPath       = TofuenvPrefix + rootPath
 	TofuToken          = TofuenvPrefix + token
 	TofuURLTemplate    = TofuenvPrefix + "URL_TEMPLATE"
+	TofuValidation     = TofuenvPrefix + validation
 )
+
+// DeclaredNames holds environment variable names of a tool declared in tools.yaml or handled by a plugin.
+type DeclaredNames struct {
+	InstallMode string
+	ListMode    string
+	ListURL     string
+	Prefix      string // like "TFLINT_" or "TERRAFORM_DOCS_"
+	RemotePass  string
+	RemoteURL   string
+	RemoteUser  string
+	Validation  string
+}
+
+func MakeDeclaredNames(toolName string) DeclaredNames {
+	prefix := strings.ToUpper(strings.ReplaceAll(toolName, "-", "_")) + "_"
+
+	return DeclaredNames{
+		InstallMode: prefix + installMode, ListMode: prefix + listMode, ListURL: prefix + listURL, Prefix: prefix,
+		RemotePass: prefix + remotePass, RemoteURL: prefix + remoteURL, RemoteUser: prefix + remoteUser, Validation: prefix + validation,
+	}
+}
@@ -0,0 +1,103 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/tofuutils/tenv/v4/config/envname"
+)
+
+const (
+	PluginPrefix = "tenv-retriever-"
+
+	pathEnvName    = "PATH"
+	pluginsDirName = "plugins"
+)
+
+// Plugin describes an external retriever executable (named PluginPrefix + Name).
+type Plugin struct {
+	Name      string
+	Path      string
+	RemoteURL string // passed to the plugin, can be empty (default to <NAME>_REMOTE env var)
+}
+
+// EnvNames returns environment variable names of the tool handled by the plugin.
+func (plugin *Plugin) EnvNames() envname.DeclaredNames {
+	return envname.MakeDeclaredNames(plugin.Name)
+}
+
+// InitPlugins searches plugin executables in TENV_ROOT/plugins then in PATH directories, sorted by name.
+// When several executables have the same name, the first found is kept ; reservedNames (built-in tools, tenv commands or declared tools) are skipped.
+func (conf *Config) InitPlugins(reservedNames []string) {
+	dirPaths := append([]string{conf.PluginsPath()}, filepath.SplitList(conf.Getenv(pathEnvName))...)
+
+	conf.Plugins = nil
+	for _, dirPath := range dirPaths {
+		if dirPath == "" {
+			continue
+		}
+
+		entries, err := os.ReadDir(dirPath)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name, ok := pluginName(entry)
+			if !ok || slices.Contains(reservedNames, name) || slices.ContainsFunc(conf.Plugins, func(plugin *Plugin) bool {
+				return plugin.Name == name
+			}) {
+				continue
+			}
+
+			conf.Plugins = append(conf.Plugins, &Plugin{Name: name, Path: filepath.Join(dirPath, entry.Name()), RemoteURL: conf.Getenv(envname.MakeDeclaredNames(name).RemoteURL)})
+		}
+	}
+
+	slices.SortFunc(conf.Plugins, func(a *Plugin, b *Plugin) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+}
+
+// PluginsPath returns the directory searched first for plugin executables.
+func (conf *Config) PluginsPath() string {
+	return filepath.Join(conf.RootPath, pluginsDirName)
+}
+
+func pluginName(entry os.DirEntry) (string, bool) {
+	name, ok := strings.CutPrefix(entry.Name(), PluginPrefix)
+	if !ok || entry.IsDir() {
+		return "", false
+	}
+
+	if runtime.GOOS == windowsName {
+		if name, ok = strings.CutSuffix(name, exeSuffix); !ok {
+			return "", false
+		}
+	} else if info, err := entry.Info(); err != nil || info.Mode()&0o111 == 0 {
+		return "", false
+	}
+
+	return name, toolNameRegexp.MatchString(name)
+}
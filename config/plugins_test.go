@@ -0,0 +1,83 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+)
+
+func TestInitPlugins(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("executable detection relies on file mode")
+	}
+
+	conf := initToolDefs(t, "")
+	pathDir := t.TempDir()
+	conf.Getenv = func(key string) string {
+		switch key {
+		case "PATH":
+			return pathDir
+		case "TFLINT_REMOTE":
+			return "https://mirror.example.com"
+		}
+
+		return ""
+	}
+
+	writePlugin(t, conf.PluginsPath(), "tenv-retriever-tflint", 0o755)
+	writePlugin(t, pathDir, "tenv-retriever-tflint", 0o755) // shadowed by the one in plugins directory
+	writePlugin(t, pathDir, "tenv-retriever-custom", 0o755)
+	writePlugin(t, pathDir, "tenv-retriever-terraform", 0o755) // reserved
+	writePlugin(t, pathDir, "tenv-retriever-readme", 0o644)    // not executable
+	writePlugin(t, pathDir, "tenv-retriever-Bad", 0o755)       // invalid name
+
+	conf.InitPlugins([]string{"terraform"})
+	if len(conf.Plugins) != 2 {
+		t.Fatal("Unmatching results, get :", conf.Plugins)
+	}
+
+	expected := []config.Plugin{
+		{Name: "custom", Path: filepath.Join(pathDir, "tenv-retriever-custom")},
+		{Name: "tflint", Path: filepath.Join(conf.PluginsPath(), "tenv-retriever-tflint"), RemoteURL: "https://mirror.example.com"},
+	}
+	for i, plugin := range conf.Plugins {
+		if *plugin != expected[i] {
+			t.Error("Unmatching results, expected", expected[i], "get", *plugin)
+		}
+	}
+}
+
+func writePlugin(t *testing.T, dirPath string, name string, perm os.FileMode) {
+	t.Helper()
+
+	if err := os.MkdirAll(dirPath, 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dirPath, name), []byte("#!/bin/sh\n"), perm); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+}
@@ -20,8 +20,11 @@ package config
 
 import (
 	"errors"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/tofuutils/tenv/v4/config/envname"
 	configutils "github.com/tofuutils/tenv/v4/config/utils"
 	"github.com/tofuutils/tenv/v4/pkg/download"
 	githuburl "github.com/tofuutils/tenv/v4/pkg/github/url"
@@ -31,6 +34,9 @@ const (
 	InstallModeDirect = "direct"
 	ListModeHTML      = "html"
 	ModeAPI           = "api"
+	ModeIndex         = "index" // static index.json files (HashiCorp releases format), served over HTTP or from a file:// directory
+
+	DefaultReleaseWorkflow = "release.yml"
 )
 
 var (
@@ -47,6 +53,16 @@ type RemoteConfig struct {
 	listURL        string // value from env
 	RemoteURL      string // value from flag
 	RemoteURLEnv   string // value from env
+	retry          retryValues
+	validation     string // value from env
+}
+
+type retryValues struct { // values from env
+	attempts      string
+	backoff       string
+	maxBackoff    string
+	rateLimitWait string
+	statusCodes   string
 }
 
 func makeDefaultRemoteConfig(defaultURL string, defaultBaseURL string) RemoteConfig {
@@ -55,10 +71,14 @@ func makeDefaultRemoteConfig(defaultURL string, defaultBaseURL string) RemoteCon
 	}
 }
 
-func makeRemoteConfig(getenv configutils.GetenvFunc, remoteURLEnvName string, listURLEnvName string, installModeEnvName string, listModeEnvName string, defaultURL string, defaultBaseURL string) RemoteConfig {
+func makeRemoteConfig(getenv configutils.GetenvFunc, remoteURLEnvName string, listURLEnvName string, installModeEnvName string, listModeEnvName string, validationEnvName string, defaultURL string, defaultBaseURL string) RemoteConfig {
 	return RemoteConfig{
 		defaultBaseURL: defaultBaseURL, defaultURL: defaultURL, installMode: getenv(installModeEnvName), listMode: getenv(listModeEnvName),
-		listURL: getenv(listURLEnvName), RemoteURLEnv: getenv(remoteURLEnvName),
+		listURL: getenv(listURLEnvName), RemoteURLEnv: getenv(remoteURLEnvName), retry: retryValues{
+			attempts: getenv(envname.TenvRetryAttempts), backoff: getenv(envname.TenvRetryBackoff),
+			maxBackoff: getenv(envname.TenvRetryMaxBackoff), rateLimitWait: getenv(envname.TenvRateLimitWait),
+			statusCodes: getenv(envname.TenvRetryStatusCodes),
+		}, validation: getenv(validationEnvName),
 	}
 }
 
@@ -84,6 +104,11 @@ func (r RemoteConfig) GetListURL() string {
 	return strings.TrimRight(r.getValueForcedDefault("list_url", r.listURL, r.GetRemoteURL()), "/")
 }
 
+// GetReleaseWorkflow returns the file name (in .github/workflows) of the GitHub Actions workflow expected to sign releases.
+func (r RemoteConfig) GetReleaseWorkflow(defaultWorkflow string) string {
+	return r.getValueForcedDefault("release_workflow", "", defaultWorkflow)
+}
+
 func (r RemoteConfig) GetRemoteURL() string {
 	remoteURL := r.RemoteURL
 	if remoteURL == "" {
@@ -93,6 +118,50 @@ func (r RemoteConfig) GetRemoteURL() string {
 	return strings.TrimRight(remoteURL, "/")
 }
 
+// GetRetryPolicy returns download.DefaultRetryPolicy overridden by remote configuration file and env values.
+func (r RemoteConfig) GetRetryPolicy() (download.RetryPolicy, error) {
+	policy := download.DefaultRetryPolicy
+
+	var err error
+	if value := r.getValueForcedDefault("retry_attempts", r.retry.attempts, ""); value != "" {
+		if policy.Attempts, err = strconv.Atoi(value); err != nil {
+			return download.RetryPolicy{}, err
+		}
+	}
+
+	if value := r.getValueForcedDefault("retry_backoff", r.retry.backoff, ""); value != "" {
+		if policy.Backoff, err = time.ParseDuration(value); err != nil {
+			return download.RetryPolicy{}, err
+		}
+	}
+
+	if value := r.getValueForcedDefault("retry_max_backoff", r.retry.maxBackoff, ""); value != "" {
+		if policy.MaxBackoff, err = time.ParseDuration(value); err != nil {
+			return download.RetryPolicy{}, err
+		}
+	}
+
+	if value := r.getValueForcedDefault("rate_limit_max_wait", r.retry.rateLimitWait, ""); value != "" {
+		if policy.MaxRateLimitWait, err = time.ParseDuration(value); err != nil {
+			return download.RetryPolicy{}, err
+		}
+	}
+
+	if value := r.getValueForcedDefault("retry_status_codes", r.retry.statusCodes, ""); value != "" {
+		parts := strings.Split(value, ",")
+		policy.RetryableStatus = make([]int, 0, len(parts))
+		for _, part := range parts {
+			statusCode, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return download.RetryPolicy{}, err
+			}
+			policy.RetryableStatus = append(policy.RetryableStatus, statusCode)
+		}
+	}
+
+	return policy, nil
+}
+
 func (r RemoteConfig) GetRewriteRule() download.URLTransformer {
 	oldBase := r.Data["old_base_url"]
 	newBase := r.Data["new_base_url"]
@@ -0,0 +1,254 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/tofuutils/tenv/v4/config/envname"
+	githuburl "github.com/tofuutils/tenv/v4/pkg/github/url"
+	"github.com/tofuutils/tenv/v4/pkg/winbin"
+)
+
+const (
+	ArchiveBinary = "binary"
+	ArchiveTarGz  = "tar.gz"
+	ArchiveZip    = "zip"
+
+	defaultTagPrefix  = "v"
+	exeSuffix         = ".exe"
+	toolsConfFileName = "tools.yaml"
+	windowsName       = "windows"
+)
+
+var (
+	ErrToolDefinition = errors.New("invalid tool definition")
+
+	toolNameRegexp   = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	repositoryRegexp = regexp.MustCompile(`^[\w.-]+/[\w.-]+$`)
+)
+
+// ToolDefinition describes a tool released on GitHub, managed without dedicated code (declared in tools.yaml).
+//
+// Asset, Binary and Checksum are text/template strings receiving Version, Tag, OS, Arch, Exe (".exe" on Windows)
+// and Ext (".zip" on Windows, ".tar.gz" elsewhere) fields.
+type ToolDefinition struct {
+	Archive      string            `yaml:"archive"`    // ArchiveBinary, ArchiveTarGz or ArchiveZip (deduced from asset suffix when empty)
+	ArchNames    map[string]string `yaml:"arch_names"` // rename GOARCH values in templates
+	Asset        string            `yaml:"asset"`
+	Binary       string            `yaml:"binary"`   // executable name in archive, default to Name with Exe
+	Checksum     string            `yaml:"checksum"` // checksums file asset, no checksum check when empty
+	DisplayName  string            `yaml:"display_name"`
+	Name         string            `yaml:"-"`
+	OSNames      map[string]string `yaml:"os_names"` // rename GOOS values in templates
+	Remote       RemoteConfig      `yaml:"-"`
+	Repository   string            `yaml:"repository"` // like "owner/name"
+	TagPrefix    *string           `yaml:"tag_prefix"` // default to "v"
+	VersionFiles []string          `yaml:"version_files"`
+	Workflow     string            `yaml:"workflow"` // release workflow file name in .github/workflows, default to DefaultReleaseWorkflow
+
+	templates [3]*template.Template // asset, binary and checksum
+}
+
+// ToolAssetNames holds ToolDefinition templates rendered for a version and a platform.
+type ToolAssetNames struct {
+	Archive  string
+	Asset    string
+	Binary   string
+	Checksum string
+	Tag      string
+	Version  string
+}
+
+type toolTemplateData struct {
+	Arch    string
+	Exe     string
+	Ext     string
+	OS      string
+	Tag     string
+	Version string
+}
+
+// AssetNames renders templates for version (with or without tag prefix) and platform.
+func (def *ToolDefinition) AssetNames(versionStr string, goos string, arch string) (ToolAssetNames, error) {
+	tagPrefix := def.tagPrefix()
+	version := versionStr
+	if tagPrefix != "" {
+		version = strings.TrimPrefix(versionStr, tagPrefix)
+	}
+
+	data := toolTemplateData{Arch: MapGetDefault(def.ArchNames, arch, arch), Ext: winbin.GetArchiveFormatForOS(goos), OS: MapGetDefault(def.OSNames, goos, goos), Tag: tagPrefix + version, Version: version}
+	if goos == windowsName {
+		data.Exe = exeSuffix
+	}
+
+	var rendered [3]string
+	for i, tmpl := range def.templates {
+		if tmpl == nil {
+			continue
+		}
+
+		var builder strings.Builder
+		if err := tmpl.Execute(&builder, data); err != nil {
+			return ToolAssetNames{}, fmt.Errorf("%w : %s : %w", ErrToolDefinition, def.Name, err)
+		}
+		rendered[i] = builder.String()
+	}
+
+	names := ToolAssetNames{Archive: def.Archive, Asset: rendered[0], Binary: rendered[1], Checksum: rendered[2], Tag: data.Tag, Version: version}
+	if names.Archive == "" {
+		names.Archive = ArchiveBinary
+		for _, archive := range []string{ArchiveTarGz, ArchiveZip} {
+			if strings.HasSuffix(names.Asset, "."+archive) {
+				names.Archive = archive
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// EnvNames returns environment variable names of the tool.
+func (def *ToolDefinition) EnvNames() envname.DeclaredNames {
+	return envname.MakeDeclaredNames(def.Name)
+}
+
+// ReleaseWorkflow returns the release workflow file name, the release_workflow remote configuration takes precedence.
+func (def *ToolDefinition) ReleaseWorkflow() string {
+	defaultWorkflow := def.Workflow
+	if defaultWorkflow == "" {
+		defaultWorkflow = DefaultReleaseWorkflow
+	}
+
+	return def.Remote.GetReleaseWorkflow(defaultWorkflow)
+}
+
+func (def *ToolDefinition) tagPrefix() string {
+	if def.TagPrefix == nil {
+		return defaultTagPrefix
+	}
+
+	return *def.TagPrefix
+}
+
+func (def *ToolDefinition) init(getenv func(string) string, name string) error {
+	def.Name = name
+	if !toolNameRegexp.MatchString(name) {
+		return fmt.Errorf("%w : name %q must contain only lowercase letters, digits and dashes", ErrToolDefinition, name)
+	}
+
+	if !repositoryRegexp.MatchString(def.Repository) {
+		return fmt.Errorf("%w : %s repository %q must be like owner/name", ErrToolDefinition, name, def.Repository)
+	}
+
+	if def.Asset == "" {
+		return fmt.Errorf("%w : %s asset is required", ErrToolDefinition, name)
+	}
+
+	switch def.Archive {
+	case "", ArchiveBinary, ArchiveTarGz, ArchiveZip:
+	default:
+		return fmt.Errorf("%w : %s archive %q must be %s, %s or %s", ErrToolDefinition, name, def.Archive, ArchiveBinary, ArchiveTarGz, ArchiveZip)
+	}
+
+	if def.Binary == "" {
+		def.Binary = name + "{{.Exe}}"
+	}
+
+	for i, text := range []string{def.Asset, def.Binary, def.Checksum} {
+		if text == "" {
+			continue
+		}
+
+		tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return fmt.Errorf("%w : %s : %w", ErrToolDefinition, name, err)
+		}
+		def.templates[i] = tmpl
+	}
+
+	if def.DisplayName == "" {
+		def.DisplayName = name
+	}
+
+	if len(def.VersionFiles) == 0 {
+		def.VersionFiles = []string{"." + name + "-version"}
+	}
+
+	envNames := def.EnvNames()
+	def.Remote = makeRemoteConfig(getenv, envNames.RemoteURL, envNames.ListURL, envNames.InstallMode, envNames.ListMode, envNames.Validation, githuburl.Default+def.Repository+githuburl.SlashReleasesSuffix, githuburl.Base)
+
+	return nil
+}
+
+// InitToolDefs reads tool definitions from TENV_TOOLS_CONF file (default to tools.yaml in root path), sorted by name.
+// reservedNames (built-in tools and tenv commands) can not be declared.
+func (conf *Config) InitToolDefs(reservedNames []string) error {
+	toolsConfPath := conf.ToolsConfPath
+	if toolsConfPath == "" {
+		toolsConfPath = filepath.Join(conf.RootPath, toolsConfFileName)
+	}
+
+	data, err := os.ReadFile(toolsConfPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+
+		return err
+	}
+
+	var toolDefs map[string]*ToolDefinition
+	if err = yaml.Unmarshal(data, &toolDefs); err != nil {
+		return fmt.Errorf("%w in %s : %w", ErrToolDefinition, toolsConfPath, err)
+	}
+
+	conf.Tools = make([]*ToolDefinition, 0, len(toolDefs))
+	for name, def := range toolDefs {
+		if slices.Contains(reservedNames, name) {
+			return fmt.Errorf("%w : %s is reserved (built-in tool or tenv command)", ErrToolDefinition, name)
+		}
+
+		if def == nil {
+			def = &ToolDefinition{}
+		}
+
+		if err = def.init(conf.Getenv, name); err != nil {
+			return err
+		}
+
+		conf.Tools = append(conf.Tools, def)
+	}
+
+	slices.SortFunc(conf.Tools, func(a *ToolDefinition, b *ToolDefinition) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return nil
+}
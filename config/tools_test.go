@@ -0,0 +1,143 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package config_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+)
+
+const toolsConf = `
+tflint:
+  repository: terraform-linters/tflint
+  asset: "tflint_{{.OS}}_{{.Arch}}.zip"
+  checksum: checksums.txt
+  workflow: build.yml
+terraform-docs:
+  display_name: terraform-docs
+  repository: terraform-docs/terraform-docs
+  asset: "terraform-docs-{{.Tag}}-{{.OS}}-{{.Arch}}{{.Ext}}"
+  checksum: "terraform-docs-{{.Tag}}.sha256sum"
+  arch_names:
+    amd64: x86_64
+`
+
+func TestInitToolDefs(t *testing.T) {
+	t.Parallel()
+
+	conf := initToolDefs(t, toolsConf)
+	if err := conf.InitToolDefs(nil); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if len(conf.Tools) != 2 || conf.Tools[0].Name != "terraform-docs" || conf.Tools[1].Name != "tflint" {
+		t.Fatal("Unmatching results, get :", conf.Tools)
+	}
+
+	tflintDef := conf.Tools[1]
+	if !slices.Equal(tflintDef.VersionFiles, []string{".tflint-version"}) || tflintDef.EnvNames().RemoteURL != "TFLINT_REMOTE" {
+		t.Error("Unmatching defaults, get :", tflintDef.VersionFiles, tflintDef.EnvNames())
+	}
+
+	if remoteURL := tflintDef.Remote.GetRemoteURL(); remoteURL != "https://api.github.com/repos/terraform-linters/tflint/releases" {
+		t.Error("Unmatching remote URL, get :", remoteURL)
+	}
+
+	if workflow := tflintDef.ReleaseWorkflow(); workflow != "build.yml" {
+		t.Error("Unmatching release workflow, get :", workflow)
+	}
+
+	if workflow := conf.Tools[0].ReleaseWorkflow(); workflow != config.DefaultReleaseWorkflow {
+		t.Error("Unmatching default release workflow, get :", workflow)
+	}
+
+	names, err := conf.Tools[0].AssetNames("v0.19.0", "windows", "amd64")
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	expected := config.ToolAssetNames{
+		Archive: config.ArchiveZip, Asset: "terraform-docs-v0.19.0-windows-x86_64.zip", Binary: "terraform-docs.exe",
+		Checksum: "terraform-docs-v0.19.0.sha256sum", Tag: "v0.19.0", Version: "0.19.0",
+	}
+	if names != expected {
+		t.Error("Unmatching results, expected", expected, "get", names)
+	}
+}
+
+func TestInitToolDefsError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		toolsConf string
+		reserved  []string
+	}{
+		{name: "Reserved", toolsConf: "terraform:\n  repository: hashicorp/terraform\n  asset: terraform.zip", reserved: []string{"terraform"}},
+		{name: "Name", toolsConf: "TFLint:\n  repository: terraform-linters/tflint\n  asset: tflint.zip"},
+		{name: "Repository", toolsConf: "tflint:\n  repository: tflint\n  asset: tflint.zip"},
+		{name: "Asset", toolsConf: "tflint:\n  repository: terraform-linters/tflint"},
+		{name: "Archive", toolsConf: "tflint:\n  repository: terraform-linters/tflint\n  asset: tflint.7z\n  archive: 7z"},
+		{name: "Template", toolsConf: "tflint:\n  repository: terraform-linters/tflint\n  asset: \"tflint_{{.OS\""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := initToolDefs(t, test.toolsConf)
+			if err := conf.InitToolDefs(test.reserved); !errors.Is(err, config.ErrToolDefinition) {
+				t.Error("Should fail on invalid tool definition, get :", err)
+			}
+		})
+	}
+}
+
+func TestInitToolDefsNoFile(t *testing.T) {
+	t.Parallel()
+
+	conf := initToolDefs(t, "")
+	if err := conf.InitToolDefs(nil); err != nil || len(conf.Tools) != 0 {
+		t.Error("Should ignore missing file, get :", conf.Tools, err)
+	}
+}
+
+// write toolsConf in a temporary root path (no file when empty).
+func initToolDefs(t *testing.T, toolsConf string) config.Config {
+	t.Helper()
+
+	conf, err := config.DefaultConfig()
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	conf.RootPath = t.TempDir()
+	if toolsConf != "" {
+		if err = os.WriteFile(filepath.Join(conf.RootPath, "tools.yaml"), []byte(toolsConf), 0o600); err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+	}
+
+	return conf
+}
@@ -18,7 +18,12 @@
 
 package configutils
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
 
 type GetenvFunc func(string) string
 
@@ -59,3 +64,24 @@ func (getenv GetenvFunc) WithDefault(defaultValue string, key string) string {
 
 	return defaultValue
 }
+
+// ParseSize parses a size in bytes, with an optional binary unit suffix (K, M, G or T, optionally followed by B or iB).
+func ParseSize(value string) (int64, error) {
+	value = strings.TrimSpace(strings.ToUpper(value))
+	value = strings.TrimSuffix(strings.TrimSuffix(value, "B"), "I")
+
+	multiplier := int64(1)
+	if length := len(value); length != 0 {
+		if index := strings.IndexByte(loghelper.SizeUnits, value[length-1]); index != -1 {
+			multiplier <<= 10 * (index + 1)
+			value = strings.TrimSpace(value[:length-1])
+		}
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return size * multiplier, nil
+}
@@ -0,0 +1,61 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package configutils_test
+
+import (
+	"strings"
+	"testing"
+
+	configutils "github.com/tofuutils/tenv/v4/config/utils"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+func TestParseSize(t *testing.T) {
+	t.Parallel()
+
+	for value, expected := range map[string]int64{"512": 512, "2K": 2048, "2 KiB": 2048, "1.5": -1, "3mb": 3 << 20, "1G": 1 << 30, "1T": 1 << 40, "": -1, "1P": -1} {
+		size, err := configutils.ParseSize(value)
+		switch {
+		case expected == -1:
+			if err == nil {
+				t.Error("Should fail on", value, ", get :", size)
+			}
+		case err != nil:
+			t.Error("Unexpected error :", err)
+		case size != expected:
+			t.Error("Unmatching results for", value, ", expected", expected, "get", size)
+		}
+	}
+}
+
+func TestParseFormattedSize(t *testing.T) {
+	t.Parallel()
+
+	// both use the same units (formatted integer values have a ".0" decimal part)
+	for _, size := range []int64{1 << 10, 7 << 20, 1 << 30, 3 << 40} {
+		parsed, err := configutils.ParseSize(strings.Replace(loghelper.FormatSize(size), ".0", "", 1))
+		if err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+
+		if parsed != size {
+			t.Error("Unmatching results, expected", size, "get", parsed)
+		}
+	}
+}
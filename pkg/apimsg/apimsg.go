@@ -18,7 +18,10 @@
 
 package apimsg
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 const (
 	AssetsName          = "assets"
@@ -32,3 +35,16 @@ var (
 	ErrReturn    = errors.New("unexpected value returned by API")
 	ErrRateLimit = errors.New("you are rate-limited by GitHub. Consider using a token by setting the TENV_GITHUB_TOKEN env variable to increase the rate limit")
 )
+
+// RateLimitError wraps ErrRateLimit with the time of rate limit reset.
+type RateLimitError struct {
+	Reset time.Time
+}
+
+func (e RateLimitError) Error() string {
+	return ErrRateLimit.Error() + " (limit reset at " + e.Reset.Local().Format(time.DateTime) + ", set TENV_RATE_LIMIT_MAX_WAIT to wait for it)"
+}
+
+func (e RateLimitError) Unwrap() error {
+	return ErrRateLimit
+}
@@ -0,0 +1,129 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package attestationcheck
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	githuburl "github.com/tofuutils/tenv/v4/pkg/github/url"
+)
+
+const (
+	GithubIssuer  = "https://token.actions.githubusercontent.com"
+	PredicateType = "https://slsa.dev/provenance/v1"
+
+	sha256Name    = "sha256"
+	tlogThreshold = 1
+	WorkflowsDir  = ".github/workflows/"
+)
+
+var (
+	ErrCheck         = errors.New("invalid build provenance attestation")
+	ErrNoAttestation = errors.New("no build provenance attestation found")
+	ErrProvenance    = errors.New("unexpected build provenance")
+)
+
+// Check verifies that one of the attestation bundles (as returned by GitHub attestations API) proves that the artifact
+// with dataSHA256 sum was built by the workflow file (in .github/workflows, like "release.yml") of repository (like "owner/name").
+// Each bundle must be issued by trustedMaterial authorities, be integrated in a transparency log and hold a SLSA provenance
+// whose workflow and certificate source repository match repository and workflow.
+//
+// Return the workflow identity (build signer URI) found in certificate.
+func Check(dataSHA256 []byte, dataBundles [][]byte, repository string, workflow string, trustedMaterial root.TrustedMaterial) (string, error) {
+	if len(dataBundles) == 0 {
+		return "", ErrNoAttestation
+	}
+
+	verifier, err := verify.NewVerifier(trustedMaterial, verify.WithTransparencyLog(tlogThreshold), verify.WithIntegratedTimestamps(tlogThreshold))
+	if err != nil {
+		return "", err
+	}
+
+	repositoryURL, workflowPath := githuburl.Base+"/"+repository, WorkflowsDir+workflow
+	expectedIdentity, err := verify.NewShortCertificateIdentity(GithubIssuer, "", "", "^"+regexp.QuoteMeta(repositoryURL+"/"+workflowPath+"@"))
+	if err != nil {
+		return "", err
+	}
+
+	policy := verify.NewPolicy(verify.WithArtifactDigest(sha256Name, dataSHA256), verify.WithCertificateIdentity(expectedIdentity))
+
+	errs := make([]error, 0, len(dataBundles))
+	for _, dataBundle := range dataBundles {
+		identity, err := checkBundle(verifier, policy, dataBundle, repositoryURL, workflowPath)
+		if err == nil {
+			return identity, nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	return "", fmt.Errorf("%w : %w", ErrCheck, errors.Join(errs...))
+}
+
+// CheckProvenance verifies predicate is a SLSA provenance of a build by the workflow at workflowPath (like ".github/workflows/release.yml")
+// of repositoryURL.
+func CheckProvenance(predicateType string, predicate *structpb.Struct, repositoryURL string, workflowPath string) error {
+	if predicateType != PredicateType {
+		return fmt.Errorf("%w : predicate type %s", ErrProvenance, predicateType)
+	}
+
+	workflow := predicate.GetFields()["buildDefinition"].GetStructValue().GetFields()["externalParameters"].GetStructValue().GetFields()["workflow"].GetStructValue().GetFields()
+	if workflowRepository := workflow["repository"].GetStringValue(); workflowRepository != repositoryURL {
+		return fmt.Errorf("%w : built from repository %q", ErrProvenance, workflowRepository)
+	}
+
+	if path := workflow["path"].GetStringValue(); path != workflowPath {
+		return fmt.Errorf("%w : built by workflow %q", ErrProvenance, path)
+	}
+
+	return nil
+}
+
+func checkBundle(verifier *verify.Verifier, policy verify.PolicyBuilder, dataBundle []byte, repositoryURL string, workflowPath string) (string, error) {
+	var attestationBundle bundle.Bundle
+	if err := attestationBundle.UnmarshalJSON(dataBundle); err != nil {
+		return "", err
+	}
+
+	result, err := verifier.Verify(&attestationBundle, policy)
+	if err != nil {
+		return "", err
+	}
+
+	if result.Statement == nil || result.Signature == nil || result.Signature.Certificate == nil {
+		return "", ErrProvenance
+	}
+
+	if sourceRepository := result.Signature.Certificate.SourceRepositoryURI; sourceRepository != repositoryURL {
+		return "", fmt.Errorf("%w : certificate issued for repository %q", ErrProvenance, sourceRepository)
+	}
+
+	if err = CheckProvenance(result.Statement.GetPredicateType(), result.Statement.GetPredicate(), repositoryURL, workflowPath); err != nil {
+		return "", err
+	}
+
+	return result.Signature.Certificate.BuildSignerURI, nil
+}
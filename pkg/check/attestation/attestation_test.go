@@ -0,0 +1,113 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package attestationcheck_test
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"errors"
+	"testing"
+
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	attestationcheck "github.com/tofuutils/tenv/v4/pkg/check/attestation"
+)
+
+const repositoryURL = "https://github.com/opentofu/opentofu"
+
+//go:embed testdata/sigstore-js-provenance.sigstore.json
+var provenanceBundle []byte
+
+//go:embed testdata/trusted_root.json
+var trustedRootJSON []byte
+
+func TestCheckNoAttestation(t *testing.T) {
+	t.Parallel()
+
+	sum := sha256.Sum256([]byte("data"))
+	if _, err := attestationcheck.Check(sum[:], nil, "opentofu/opentofu", "release.yml", loadTrustedRoot(t)); !errors.Is(err, attestationcheck.ErrNoAttestation) {
+		t.Error("Should fail without attestation, get :", err)
+	}
+}
+
+func TestCheckErrorBundle(t *testing.T) {
+	t.Parallel()
+
+	sum := sha256.Sum256([]byte("data"))
+	dataBundles := [][]byte{[]byte("{}"), provenanceBundle} // second one is valid but does not attest data
+	if _, err := attestationcheck.Check(sum[:], dataBundles, "sigstore/sigstore-js", "release.yml", loadTrustedRoot(t)); !errors.Is(err, attestationcheck.ErrCheck) {
+		t.Error("Should fail on unmatching attestations, get :", err)
+	}
+}
+
+func TestCheckProvenance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		predicateType string
+		repository    string
+		path          string
+		wantErr       bool
+	}{
+		{name: "Correct", predicateType: attestationcheck.PredicateType, repository: repositoryURL, path: ".github/workflows/release.yml"},
+		{name: "ErrorType", predicateType: "https://slsa.dev/provenance/v0.2", repository: repositoryURL, path: ".github/workflows/release.yml", wantErr: true},
+		{name: "ErrorRepository", predicateType: attestationcheck.PredicateType, repository: "https://github.com/opentofu/fork", path: ".github/workflows/release.yml", wantErr: true},
+		{name: "ErrorPath", predicateType: attestationcheck.PredicateType, repository: repositoryURL, path: "release.yml", wantErr: true},
+		{name: "ErrorWorkflow", predicateType: attestationcheck.PredicateType, repository: repositoryURL, path: ".github/workflows/other.yml", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			predicate, err := structpb.NewStruct(map[string]any{
+				"buildDefinition": map[string]any{
+					"externalParameters": map[string]any{
+						"workflow": map[string]any{"path": test.path, "ref": "refs/tags/v1.9.0", "repository": test.repository},
+					},
+				},
+			})
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			err = attestationcheck.CheckProvenance(test.predicateType, predicate, repositoryURL, ".github/workflows/release.yml")
+			if test.wantErr {
+				if !errors.Is(err, attestationcheck.ErrProvenance) {
+					t.Error("Should fail on unexpected provenance, get :", err)
+				}
+			} else if err != nil {
+				t.Error("Unexpected error :", err)
+			}
+		})
+	}
+}
+
+func loadTrustedRoot(t *testing.T) root.TrustedMaterial {
+	t.Helper()
+
+	trustedRoot, err := root.NewTrustedRootFromJSON(trustedRootJSON)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	return trustedRoot
+}
@@ -41,7 +41,17 @@ var (
 	ErrNotInstalled = errors.New("cosign executable not found")
 )
 
+// Check verifies dataSig over data with cosign executable, which also checks Rekor transparency log inclusion.
 func Check(ctx context.Context, data []byte, dataSig []byte, dataCert []byte, certIdentity string, certOidcIssuer string, displayer loghelper.Displayer) error {
+	return check(ctx, data, dataSig, dataCert, "--certificate-identity", certIdentity, certOidcIssuer, displayer)
+}
+
+// CheckRegexp is like Check with an identity matching certIdentityRegexp.
+func CheckRegexp(ctx context.Context, data []byte, dataSig []byte, dataCert []byte, certIdentityRegexp string, certOidcIssuer string, displayer loghelper.Displayer) error {
+	return check(ctx, data, dataSig, dataCert, "--certificate-identity-regexp", certIdentityRegexp, certOidcIssuer, displayer)
+}
+
+func check(ctx context.Context, data []byte, dataSig []byte, dataCert []byte, identityFlag string, identityValue string, certOidcIssuer string, displayer loghelper.Displayer) error {
 	_, err := exec.LookPath(cosignExecName)
 	if err != nil {
 		return ErrNotInstalled
@@ -66,7 +76,7 @@ func Check(ctx context.Context, data []byte, dataSig []byte, dataCert []byte, ce
 	defer remove()
 
 	cmdArgs := []string{
-		"verify-blob", "--certificate-identity", certIdentity, "--signature", dataSigFileName, "--certificate", dataCertFileName,
+		"verify-blob", identityFlag, identityValue, "--signature", dataSigFileName, "--certificate", dataCertFileName,
 		"--certificate-oidc-issuer", certOidcIssuer, dataFileName,
 	}
 
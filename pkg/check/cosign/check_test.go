@@ -20,17 +20,33 @@ package cosigncheck_test
 
 import (
 	_ "embed"
+	"encoding/hex"
+	"errors"
 	"testing"
 
+	"github.com/sigstore/sigstore-go/pkg/root"
+
 	cosigncheck "github.com/tofuutils/tenv/v4/pkg/check/cosign"
 	"github.com/tofuutils/tenv/v4/pkg/loghelper"
 )
 
 const (
+	bundleIdentityRegexp = "^foo!"
+	bundleIssuer         = "http://oidc.local:8080"
+
 	identity = "https://github.com/opentofu/opentofu/.github/workflows/release.yml@refs/heads/v1.6"
 	issuer   = "https://token.actions.githubusercontent.com"
 )
 
+// bundle and trusted root from sigstore-go test data (signed artifact is not available, only its sum).
+var bundleDataSum, _ = hex.DecodeString("bc103b4a84971ef6459b294a2b98568a2bfb72cded09d4acd1e16366a401f95b")
+
+//go:embed testdata/othername.sigstore.json
+var bundleData []byte
+
+//go:embed testdata/scaffolding_trusted_root.json
+var scaffoldingRootJSON []byte
+
 //go:embed testdata/tofu_1.6.0_SHA256SUMS
 var data []byte
 
@@ -78,3 +94,149 @@ func TestCosignCheckErrorSig(t *testing.T) { //nolint
 		t.Error("Should fail on erroneous signature")
 	}
 }
+
+//go:embed testdata/trusted_root.json
+var trustedRootJSON []byte
+
+func TestCosignCheckNativeCorrect(t *testing.T) {
+	t.Parallel()
+
+	if err := cosigncheck.CheckNative(data, dataSig, dataCert, identity, issuer, loadTrustedRoot(t)); err != nil {
+		t.Error("Unexpected error :", err)
+	}
+}
+
+func TestCosignCheckNativeErrorCert(t *testing.T) {
+	t.Parallel()
+
+	if cosigncheck.CheckNative(data, dataSig, dataCert[1:], identity, issuer, loadTrustedRoot(t)) == nil {
+		t.Error("Should fail on erroneous certificate")
+	}
+}
+
+func TestCosignCheckNativeErrorData(t *testing.T) {
+	t.Parallel()
+
+	if cosigncheck.CheckNative(data[1:], dataSig, dataCert, identity, issuer, loadTrustedRoot(t)) == nil {
+		t.Error("Should fail on erroneous data")
+	}
+}
+
+func TestCosignCheckNativeErrorIdentity(t *testing.T) {
+	t.Parallel()
+
+	if cosigncheck.CheckNative(data, dataSig, dataCert, "me", issuer, loadTrustedRoot(t)) == nil {
+		t.Error("Should fail on erroneous identity")
+	}
+}
+
+func TestCosignCheckNativeErrorIssuer(t *testing.T) {
+	t.Parallel()
+
+	if cosigncheck.CheckNative(data, dataSig, dataCert, identity, "http://myself.com", loadTrustedRoot(t)) == nil {
+		t.Error("Should fail on erroneous issuer")
+	}
+}
+
+func TestCosignCheckNativeErrorSig(t *testing.T) {
+	t.Parallel()
+
+	if cosigncheck.CheckNative(data, dataSig[1:], dataCert, identity, issuer, loadTrustedRoot(t)) == nil {
+		t.Error("Should fail on erroneous signature")
+	}
+}
+
+func TestCosignCheckNativeErrorTrustedRoot(t *testing.T) {
+	t.Parallel()
+
+	emptyRoot, err := root.NewTrustedRoot(root.TrustedRootMediaType01, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if cosigncheck.CheckNative(data, dataSig, dataCert, identity, issuer, emptyRoot) == nil {
+		t.Error("Should fail on unknown certificate authority")
+	}
+}
+
+func TestCosignCheckNativeRegexp(t *testing.T) {
+	t.Parallel()
+
+	foundIdentity, err := cosigncheck.CheckNativeRegexp(data, dataSig, dataCert, "^https://github\\.com/opentofu/opentofu/", issuer, loadTrustedRoot(t))
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if foundIdentity != identity {
+		t.Error("Unmatching results, expected", identity, "get", foundIdentity)
+	}
+
+	if _, err = cosigncheck.CheckNativeRegexp(data, dataSig, dataCert, "^https://github\\.com/opentofu/fork/", issuer, loadTrustedRoot(t)); err == nil {
+		t.Error("Should fail on unmatching identity regexp")
+	}
+}
+
+func loadTrustedRoot(t *testing.T) root.TrustedMaterial {
+	t.Helper()
+
+	trustedRoot, err := root.NewTrustedRootFromJSON(trustedRootJSON)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	return trustedRoot
+}
+
+func TestCosignCheckBundle(t *testing.T) {
+	t.Parallel()
+
+	trustedRoot, err := root.NewTrustedRootFromJSON(scaffoldingRootJSON)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	t.Run("Correct", func(t *testing.T) {
+		t.Parallel()
+
+		identity, err := cosigncheck.CheckBundle(bundleDataSum, bundleData, bundleIdentityRegexp, bundleIssuer, trustedRoot)
+		if err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+
+		if identity != "foo!oidc.local" {
+			t.Error("Unmatching results, expected foo!oidc.local, get", identity)
+		}
+	})
+
+	t.Run("ErrorBundle", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := cosigncheck.CheckBundle(bundleDataSum, bundleData[1:], bundleIdentityRegexp, bundleIssuer, trustedRoot); !errors.Is(err, cosigncheck.ErrInvalidBundle) {
+			t.Error("Should fail on erroneous bundle, get :", err)
+		}
+	})
+
+	t.Run("ErrorData", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := cosigncheck.CheckBundle(bundleDataSum[1:], bundleData, bundleIdentityRegexp, bundleIssuer, trustedRoot); !errors.Is(err, cosigncheck.ErrCheck) {
+			t.Error("Should fail on erroneous data, get :", err)
+		}
+	})
+
+	t.Run("ErrorIdentity", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := cosigncheck.CheckBundle(bundleDataSum, bundleData, "^bar", bundleIssuer, trustedRoot); !errors.Is(err, cosigncheck.ErrCheck) {
+			t.Error("Should fail on erroneous identity, get :", err)
+		}
+	})
+
+	t.Run("ErrorIssuer", func(t *testing.T) {
+		t.Parallel()
+
+		if _, err := cosigncheck.CheckBundle(bundleDataSum, bundleData, bundleIdentityRegexp, issuer, trustedRoot); !errors.Is(err, cosigncheck.ErrCheck) {
+			t.Error("Should fail on erroneous issuer, get :", err)
+		}
+	})
+}
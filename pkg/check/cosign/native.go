@@ -0,0 +1,197 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package cosigncheck
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/sigstore/sigstore-go/pkg/bundle"
+	"github.com/sigstore/sigstore-go/pkg/fulcio/certificate"
+	"github.com/sigstore/sigstore-go/pkg/root"
+	"github.com/sigstore/sigstore-go/pkg/tuf"
+	"github.com/sigstore/sigstore-go/pkg/verify"
+	"github.com/theupdateframework/go-tuf/v2/metadata/fetcher"
+)
+
+const (
+	sha256Name    = "sha256"
+	sctThreshold  = 1
+	tlogThreshold = 1
+)
+
+var (
+	ErrInvalidBundle    = errors.New("invalid Sigstore bundle")
+	ErrInvalidCert      = errors.New("invalid certificate")
+	ErrUnsupportedKey   = errors.New("unsupported certificate public key type")
+	errNoCertificatePEM = errors.New("no PEM encoded certificate found")
+)
+
+// CheckBundle verifies in process a Sigstore bundle (as produced by "cosign sign-blob --bundle") over data with dataSHA256 sum.
+// The bundle certificate must be issued by trustedMaterial authorities to an identity matching certIdentityRegexp
+// from certOidcIssuer, and the signature must be integrated in a transparency log (proof is included in bundle).
+// Return the identity found in certificate.
+func CheckBundle(dataSHA256 []byte, dataBundle []byte, certIdentityRegexp string, certOidcIssuer string, trustedMaterial root.TrustedMaterial) (string, error) {
+	var sigBundle bundle.Bundle
+	if err := sigBundle.UnmarshalJSON(dataBundle); err != nil {
+		return "", fmt.Errorf("%w : %w", ErrInvalidBundle, err)
+	}
+
+	verifier, err := verify.NewVerifier(trustedMaterial, verify.WithTransparencyLog(tlogThreshold), verify.WithIntegratedTimestamps(tlogThreshold))
+	if err != nil {
+		return "", err
+	}
+
+	expectedIdentity, err := verify.NewShortCertificateIdentity(certOidcIssuer, "", "", certIdentityRegexp)
+	if err != nil {
+		return "", err
+	}
+
+	policy := verify.NewPolicy(verify.WithArtifactDigest(sha256Name, dataSHA256), verify.WithCertificateIdentity(expectedIdentity))
+	result, err := verifier.Verify(&sigBundle, policy)
+	if err != nil {
+		return "", fmt.Errorf("%w : %w", ErrCheck, err)
+	}
+
+	if result.Signature == nil || result.Signature.Certificate == nil {
+		return "", ErrCheck
+	}
+
+	return result.Signature.Certificate.SubjectAlternativeName, nil
+}
+
+// CheckNative verifies in process (without cosign executable) dataSig over data with dataCert public key.
+// The certificate must chain to a Fulcio authority of trustedMaterial (at its issuance time), embed a valid timestamp
+// from a known certificate transparency log and match certIdentity and certOidcIssuer.
+//
+// Unlike "cosign verify-blob", Rekor transparency log inclusion is not checked (it needs network access),
+// callers must record it as a weaker check (config.SignNoTlogValidation).
+func CheckNative(data []byte, dataSig []byte, dataCert []byte, certIdentity string, certOidcIssuer string, trustedMaterial root.TrustedMaterial) error {
+	_, err := checkNative(data, dataSig, dataCert, certIdentity, "", certOidcIssuer, trustedMaterial)
+
+	return err
+}
+
+// CheckNativeRegexp is like CheckNative with an identity matching certIdentityRegexp.
+// Return the identity found in certificate.
+func CheckNativeRegexp(data []byte, dataSig []byte, dataCert []byte, certIdentityRegexp string, certOidcIssuer string, trustedMaterial root.TrustedMaterial) (string, error) {
+	return checkNative(data, dataSig, dataCert, "", certIdentityRegexp, certOidcIssuer, trustedMaterial)
+}
+
+func checkNative(data []byte, dataSig []byte, dataCert []byte, certIdentity string, certIdentityRegexp string, certOidcIssuer string, trustedMaterial root.TrustedMaterial) (string, error) {
+	cert, err := parseCert(dataCert)
+	if err != nil {
+		return "", fmt.Errorf("%w : %w", ErrInvalidCert, err)
+	}
+
+	chains, err := verify.VerifyLeafCertificate(cert.NotBefore, cert, trustedMaterial)
+	if err != nil {
+		return "", fmt.Errorf("%w : %w", ErrCheck, err)
+	}
+
+	if err = verify.VerifySignedCertificateTimestamp(chains, sctThreshold, trustedMaterial); err != nil {
+		return "", fmt.Errorf("%w : %w", ErrCheck, err)
+	}
+
+	summary, err := certificate.SummarizeCertificate(cert)
+	if err != nil {
+		return "", fmt.Errorf("%w : %w", ErrInvalidCert, err)
+	}
+
+	expectedIdentity, err := verify.NewShortCertificateIdentity(certOidcIssuer, "", certIdentity, certIdentityRegexp)
+	if err != nil {
+		return "", err
+	}
+
+	if err = expectedIdentity.Verify(summary); err != nil {
+		return "", fmt.Errorf("%w : %w", ErrCheck, err)
+	}
+
+	if err = checkSignature(cert, data, decodeBase64(dataSig)); err != nil {
+		return "", fmt.Errorf("%w : %w", ErrCheck, err)
+	}
+
+	return summary.SubjectAlternativeName, nil
+}
+
+// TrustedRoot reads Sigstore trusted root from trustedRootPath, or when empty, fetches it from Sigstore TUF repository
+// (metadata are cached in cachePath and reused without update in offline mode).
+func TrustedRoot(trustedRootPath string, cachePath string, offline bool, client *http.Client) (root.TrustedMaterial, error) {
+	if trustedRootPath != "" {
+		return root.NewTrustedRootFromPath(trustedRootPath)
+	}
+
+	options := tuf.DefaultOptions().WithCachePath(cachePath)
+	if offline {
+		options = options.WithForceCache()
+	}
+
+	if client != nil {
+		tufFetcher := fetcher.NewDefaultFetcher()
+		tufFetcher.SetHTTPClient(client)
+		options = options.WithFetcher(tufFetcher)
+	}
+
+	return root.FetchTrustedRootWithOptions(options)
+}
+
+func checkSignature(cert *x509.Certificate, data []byte, dataSig []byte) error {
+	var algo x509.SignatureAlgorithm
+	switch cert.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		algo = x509.ECDSAWithSHA256
+	case ed25519.PublicKey:
+		algo = x509.PureEd25519
+	case *rsa.PublicKey:
+		algo = x509.SHA256WithRSA
+	default:
+		return ErrUnsupportedKey
+	}
+
+	return cert.CheckSignature(algo, data, dataSig)
+}
+
+// cosign use base64 encoding on signature and certificate (raw content is also accepted).
+func decodeBase64(data []byte) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return data
+	}
+
+	return decoded
+}
+
+func parseCert(dataCert []byte) (*x509.Certificate, error) {
+	block, _ := pem.Decode(dataCert)
+	if block == nil {
+		if block, _ = pem.Decode(decodeBase64(dataCert)); block == nil {
+			return nil, errNoCertificatePEM
+		}
+	}
+
+	return x509.ParseCertificate(block.Bytes)
+}
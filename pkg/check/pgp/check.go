@@ -32,11 +32,23 @@ var (
 )
 
 func Check(data []byte, dataSig []byte, dataPublicKey []byte) error {
-	keys, err := extractKeys(dataPublicKey)
+	_, err := Verify(data, dataSig, dataPublicKey)
+
+	return err
+}
+
+// Verify returns the fingerprint of the key validating the signature.
+func Verify(data []byte, dataSig []byte, dataPublicKey []byte) (string, error) {
+	keys, err := ParseKeys(dataPublicKey)
 	if err != nil {
-		return err
+		return "", err
 	}
 
+	return VerifyKeys(data, dataSig, keys)
+}
+
+// VerifyKeys returns the fingerprint of the first key validating the signature.
+func VerifyKeys(data []byte, dataSig []byte, keys []*crypto.Key) (string, error) {
 	pgpSignature := crypto.NewPGPSignature(dataSig)
 	message := crypto.NewPlainMessage(data)
 
@@ -47,14 +59,15 @@ func Check(data []byte, dataSig []byte, dataPublicKey []byte) error {
 		}
 
 		if err = keyRing.VerifyDetached(message, pgpSignature, crypto.GetUnixTime()); err == nil {
-			return nil
+			return key.GetFingerprint(), nil
 		}
 	}
 
-	return ErrCheck
+	return "", ErrCheck
 }
 
-func extractKeys(dataPublicKey []byte) ([]*crypto.Key, error) {
+// ParseKeys splits and parses each armored public key block of dataPublicKey.
+func ParseKeys(dataPublicKey []byte) ([]*crypto.Key, error) {
 	keyStr := string(dataPublicKey)
 	keySep := "-----BEGIN PGP PUBLIC KEY BLOCK-----"
 
@@ -57,3 +57,16 @@ func TestPgpCheckErrorSig(t *testing.T) {
 		t.Error("Should fail on erroneous signature")
 	}
 }
+
+func TestPgpVerifyFingerprint(t *testing.T) {
+	t.Parallel()
+
+	fingerprint, err := pgpcheck.Verify(data, dataSig, dataKey)
+	if err != nil {
+		t.Fatal("Unexpected error : ", err)
+	}
+
+	if fingerprint != "c874011f0ab405110d02105534365d9472d7468f" {
+		t.Error("Unexpected fingerprint, get :", fingerprint)
+	}
+}
@@ -0,0 +1,255 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pgpcheck
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/gopenpgp/v2/crypto"
+
+	"github.com/tofuutils/tenv/v4/pkg/fileperm"
+)
+
+const (
+	// ExpiryWarnDelay is the remaining validity under which a stored key is reported as expiring soon.
+	ExpiryWarnDelay = 30 * 24 * time.Hour
+
+	keyExt = ".asc"
+)
+
+var (
+	ErrFingerprint = errors.New("pgp key fingerprint does not match")
+	ErrNotStored   = errors.New("pgp key not found in trust store")
+)
+
+// KeyInfo describes a key of the trust store.
+type KeyInfo struct {
+	Fingerprint string
+	UserID      string
+	Expiry      time.Time // zero when the key never expires
+	Revoked     bool
+}
+
+func (info KeyInfo) Expired(now time.Time) bool {
+	return !info.Expiry.IsZero() && !now.Before(info.Expiry)
+}
+
+// ExpireSoon returns true when the key expires in less than ExpiryWarnDelay (or is already expired).
+func (info KeyInfo) ExpireSoon(now time.Time) bool {
+	return !info.Expiry.IsZero() && now.Add(ExpiryWarnDelay).After(info.Expiry)
+}
+
+// Status returns a readable state of the key at now ("revoked", "expired", "expire soon (date)", "valid until date" or "valid").
+func (info KeyInfo) Status(now time.Time) string {
+	switch {
+	case info.Revoked:
+		return "revoked"
+	case info.Expired(now):
+		return "expired"
+	case info.ExpireSoon(now):
+		return "expire soon (" + info.Expiry.Format(time.DateOnly) + ")"
+	case info.Expiry.IsZero():
+		return "valid"
+	}
+
+	return "valid until " + info.Expiry.Format(time.DateOnly)
+}
+
+// Store is a directory of armored public keys, each file is named after the fingerprint of its key (which pin it).
+type Store struct {
+	dirPath string
+}
+
+func NewStore(dirPath string) Store {
+	return Store{dirPath: dirPath}
+}
+
+// Add stores each key of dataPublicKey, when pinnedFingerprint is not empty only the matching key is stored.
+func (s Store) Add(dataPublicKey []byte, pinnedFingerprint string) ([]KeyInfo, error) {
+	keys, err := ParseKeys(dataPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	if pinnedFingerprint != "" {
+		pinnedFingerprint = NormalizeFingerprint(pinnedFingerprint)
+		keys = slices.DeleteFunc(keys, func(key *crypto.Key) bool {
+			return key.GetFingerprint() != pinnedFingerprint
+		})
+		if len(keys) == 0 {
+			return nil, fmt.Errorf("%w : expected %s", ErrFingerprint, pinnedFingerprint)
+		}
+	}
+
+	if err = os.MkdirAll(s.dirPath, fileperm.RWE); err != nil {
+		return nil, err
+	}
+
+	infos := make([]KeyInfo, 0, len(keys))
+	for i, key := range keys {
+		info := keyInfo(key)
+		if slices.ContainsFunc(keys[i+1:], func(other *crypto.Key) bool {
+			return other.GetFingerprint() == info.Fingerprint && !expireBefore(keyInfo(other), info)
+		}) {
+			continue // a later block refresh the same key with an equal or longer validity
+		}
+
+		armored, err := key.GetArmoredPublicKey()
+		if err != nil {
+			return nil, err
+		}
+
+		if err = os.WriteFile(filepath.Join(s.dirPath, key.GetFingerprint()+keyExt), []byte(armored), fileperm.RW); err != nil {
+			return nil, err
+		}
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// Keys returns usable stored keys, revoked and expired ones are skipped and keys expiring soon are reported with display.
+//
+// Return an empty slice when the store is empty (or does not exist), and ErrNoKey when no stored key is usable.
+func (s Store) Keys(display func(string)) ([]*crypto.Key, error) {
+	keys, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	usableKeys := make([]*crypto.Key, 0, len(keys))
+	for _, key := range keys {
+		info := keyInfo(key)
+		switch {
+		case info.Revoked, info.Expired(now):
+			display(fmt.Sprintf("Warning, skip %s pgp key %s (%s)", info.Status(now), info.Fingerprint, info.UserID))
+
+			continue
+		case info.ExpireSoon(now):
+			display(fmt.Sprintf("Warning, pgp key %s (%s) will %s", info.Fingerprint, info.UserID, info.Status(now)))
+		}
+
+		usableKeys = append(usableKeys, key)
+	}
+
+	if len(keys) != 0 && len(usableKeys) == 0 {
+		return nil, fmt.Errorf("%w in %s", ErrNoKey, s.dirPath)
+	}
+
+	return usableKeys, nil
+}
+
+// List returns informations on every stored key, sorted by fingerprint.
+func (s Store) List() ([]KeyInfo, error) {
+	keys, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]KeyInfo, 0, len(keys))
+	for _, key := range keys {
+		infos = append(infos, keyInfo(key))
+	}
+
+	return infos, nil
+}
+
+func (s Store) Remove(fingerprint string) error {
+	err := os.Remove(filepath.Join(s.dirPath, NormalizeFingerprint(fingerprint)+keyExt))
+	if errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%w : %s", ErrNotStored, fingerprint)
+	}
+
+	return err
+}
+
+// load reads every stored key and check it matches its file name.
+func (s Store) load() ([]*crypto.Key, error) {
+	entries, err := os.ReadDir(s.dirPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	keys := make([]*crypto.Key, 0, len(entries))
+	for _, entry := range entries {
+		fileName := entry.Name()
+		pinnedFingerprint, ok := strings.CutSuffix(fileName, keyExt)
+		if entry.IsDir() || !ok {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(s.dirPath, fileName))
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := crypto.NewKeyFromArmored(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("%w : %s", ErrKeyBlock, fileName)
+		}
+
+		if fingerprint := key.GetFingerprint(); fingerprint != pinnedFingerprint {
+			return nil, fmt.Errorf("%w : %s contains %s", ErrFingerprint, fileName, fingerprint)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// NormalizeFingerprint lowers fingerprint and removes spaces and hexadecimal prefix.
+func NormalizeFingerprint(fingerprint string) string {
+	fingerprint = strings.ToLower(strings.ReplaceAll(fingerprint, " ", ""))
+
+	return strings.TrimPrefix(fingerprint, "0x")
+}
+
+func expireBefore(info KeyInfo, other KeyInfo) bool {
+	return !info.Expiry.IsZero() && (other.Expiry.IsZero() || info.Expiry.Before(other.Expiry))
+}
+
+func keyInfo(key *crypto.Key) KeyInfo {
+	info := KeyInfo{Fingerprint: key.GetFingerprint(), Revoked: key.IsRevoked()}
+
+	entity := key.GetEntity()
+	selfSig, identity := entity.PrimarySelfSignature()
+	if identity != nil {
+		info.UserID = identity.Name
+	}
+
+	if selfSig != nil && selfSig.KeyLifetimeSecs != nil && *selfSig.KeyLifetimeSecs != 0 {
+		info.Expiry = entity.PrimaryKey.CreationTime.Add(time.Duration(*selfSig.KeyLifetimeSecs) * time.Second)
+	}
+
+	return info
+}
@@ -0,0 +1,141 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pgpcheck_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pgpcheck "github.com/tofuutils/tenv/v4/pkg/check/pgp"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+const hashicorpFingerprint = "c874011f0ab405110d02105534365d9472d7468f"
+
+func TestStoreAddVerify(t *testing.T) {
+	t.Parallel()
+
+	store := pgpcheck.NewStore(t.TempDir())
+	infos, err := store.Add(dataKey, "C874 011F 0AB4 0511 0D02 1055 3436 5D94 72D7 468F")
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	// the two blocks of the file share the fingerprint, only the one with the longer validity is kept
+	if len(infos) != 1 || infos[0].Fingerprint != hashicorpFingerprint || infos[0].Expiry.Year() != 2030 {
+		t.Fatal("Unmatching results, get :", infos)
+	}
+
+	keys, err := store.Keys(loghelper.InertDisplayer.Display)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	fingerprint, err := pgpcheck.VerifyKeys(data, dataSig, keys)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if fingerprint != hashicorpFingerprint {
+		t.Error("Unexpected fingerprint, get :", fingerprint)
+	}
+}
+
+func TestStoreAddWrongPin(t *testing.T) {
+	t.Parallel()
+
+	store := pgpcheck.NewStore(t.TempDir())
+	if _, err := store.Add(dataKey, "0xDEADBEEF"); !errors.Is(err, pgpcheck.ErrFingerprint) {
+		t.Error("Should fail on unmatching pinned fingerprint, get :", err)
+	}
+
+	if infos, err := store.List(); err != nil || len(infos) != 0 {
+		t.Error("Store should be empty, get :", infos, err)
+	}
+}
+
+func TestStoreTampered(t *testing.T) {
+	t.Parallel()
+
+	dirPath := t.TempDir()
+	store := pgpcheck.NewStore(dirPath)
+	if _, err := store.Add(dataKey, ""); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err := os.Rename(filepath.Join(dirPath, hashicorpFingerprint+".asc"), filepath.Join(dirPath, "0123456789abcdef.asc")); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if _, err := store.Keys(loghelper.InertDisplayer.Display); !errors.Is(err, pgpcheck.ErrFingerprint) {
+		t.Error("Should fail on key not matching its file name, get :", err)
+	}
+}
+
+func TestStoreRemove(t *testing.T) {
+	t.Parallel()
+
+	store := pgpcheck.NewStore(t.TempDir())
+	if _, err := store.Add(dataKey, ""); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err := store.Remove(hashicorpFingerprint); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err := store.Remove(hashicorpFingerprint); !errors.Is(err, pgpcheck.ErrNotStored) {
+		t.Error("Should fail on absent key, get :", err)
+	}
+
+	keys, err := store.Keys(loghelper.InertDisplayer.Display)
+	if err != nil || len(keys) != 0 {
+		t.Error("Store should be empty, get :", keys, err)
+	}
+}
+
+func TestKeyInfoStatus(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		info pgpcheck.KeyInfo
+		want string
+	}{
+		{name: "NoExpiry", info: pgpcheck.KeyInfo{}, want: "valid"},
+		{name: "Valid", info: pgpcheck.KeyInfo{Expiry: now.AddDate(1, 0, 0)}, want: "valid until 2027-10-17"},
+		{name: "Soon", info: pgpcheck.KeyInfo{Expiry: now.AddDate(0, 0, 10)}, want: "expire soon (2026-10-27)"},
+		{name: "Expired", info: pgpcheck.KeyInfo{Expiry: now.AddDate(0, 0, -1)}, want: "expired"},
+		{name: "Revoked", info: pgpcheck.KeyInfo{Expiry: now.AddDate(1, 0, 0), Revoked: true}, want: "revoked"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			if status := test.info.Status(now); status != test.want {
+				t.Error("Unmatching results, expected", test.want, "get", status)
+			}
+		})
+	}
+}
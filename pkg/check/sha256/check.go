@@ -23,6 +23,8 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"io"
+	"os"
 	"strings"
 )
 
@@ -32,20 +34,42 @@ var (
 )
 
 func Check(data []byte, dataSums []byte, fileName string) error {
-	dataSum, err := extract(dataSums, fileName)
+	hashed := sha256.Sum256(data)
+
+	return CheckSum(hashed[:], dataSums, fileName)
+}
+
+// CheckSum compares an already computed sha256 (like one from a streamed download).
+func CheckSum(sum []byte, dataSums []byte, fileName string) error {
+	dataSum, err := Extract(dataSums, fileName)
 	if err != nil {
 		return err
 	}
 
-	hashed := sha256.Sum256(data)
-	if !bytes.Equal(dataSum, hashed[:]) {
+	if !bytes.Equal(dataSum, sum) {
 		return ErrCheck
 	}
 
 	return nil
 }
 
-func extract(dataSums []byte, fileName string) ([]byte, error) {
+// File returns the sha256 of the content of the file at filePath.
+func File(filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err = io.Copy(hasher, file); err != nil {
+		return nil, err
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+func Extract(dataSums []byte, fileName string) ([]byte, error) {
 	dataSumsStr := string(dataSums)
 	for _, dataSumStr := range strings.Split(dataSumsStr, "\n") {
 		dataSumStr, ok := strings.CutSuffix(dataSumStr, fileName)
@@ -21,6 +21,7 @@ package sha256check_test
 import (
 	_ "embed"
 	"errors"
+	"os"
 	"testing"
 
 	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
@@ -59,3 +60,20 @@ func TestSha256Extract(t *testing.T) {
 		t.Error("Incorrect error reported, get :", err)
 	}
 }
+
+func TestSha256File(t *testing.T) {
+	t.Parallel()
+
+	sum, err := sha256check.File("testdata/hello.txt")
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err = sha256check.CheckSum(sum, dataSums, "hello.txt"); err != nil {
+		t.Error("Unexpected error :", err)
+	}
+
+	if _, err = sha256check.File("testdata/missing.txt"); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Should fail on missing file, get :", err)
+	}
+}
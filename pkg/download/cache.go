@@ -0,0 +1,494 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
+	"github.com/tofuutils/tenv/v4/pkg/fileperm"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+const (
+	blobDirName  = "blobs"
+	indexDirName = "urls"
+	tmpPrefix    = ".tmp-"
+)
+
+// blobs returned by File and not yet released (by path, shared by all Cache values of the process),
+// pruning skips them : concurrent installations can still be extracting them.
+var blobsInUse = struct {
+	sync.Mutex
+	counts map[string]int
+}{counts: map[string]int{}}
+
+type CacheEntry struct {
+	LastUse time.Time
+	SHA256  string
+	Size    int64
+	URLs    []string
+}
+
+type cacheIndex struct {
+	SHA256 string `json:"sha256"`
+	URL    string `json:"url"`
+}
+
+// Content addressed cache : each downloaded content is stored once (named by its sha256),
+// and an index file per URL (named by the URL sha256) point to it.
+//
+// A nil *Cache is valid and disable caching.
+type Cache struct {
+	dirPath string
+	maxSize int64 // 0 or less means no limit
+}
+
+func NewCache(dirPath string, maxSize int64) *Cache {
+	return &Cache{dirPath: dirPath, maxSize: maxSize}
+}
+
+// Bytes returns content from cache when present, otherwise download it and store it in cache.
+func (c *Cache) Bytes(ctx context.Context, url string, display func(string), checker ResponseChecker, requestOptions ...RequestOption) ([]byte, error) {
+	if c == nil || IsReplay(ctx) {
+		return Bytes(ctx, url, display, checker, requestOptions...)
+	}
+
+	if data, ok := c.Get(url); ok {
+		display("Using cached " + url)
+		Record(ctx, url, data)
+
+		return data, nil
+	}
+
+	cacheable := false
+	cacheChecker := func(response *http.Response) error {
+		if err := checker(response); err != nil {
+			return err
+		}
+		cacheable = response.StatusCode == http.StatusOK
+
+		return nil
+	}
+
+	data, err := Bytes(ctx, url, display, cacheChecker, requestOptions...)
+	if err != nil || !cacheable {
+		return data, err
+	}
+
+	if err = c.Put(url, data); err != nil {
+		display("Unable to store download in cache : " + err.Error())
+	}
+
+	return data, nil
+}
+
+// File returns a file from cache when present, otherwise stream the download into cache.
+func (c *Cache) File(ctx context.Context, url string, display func(string), progress func(loghelper.Progress), checker ResponseChecker, requestOptions ...RequestOption) (File, error) {
+	if c == nil {
+		return ToFile(ctx, url, display, progress, checker, requestOptions...)
+	}
+
+	if sha256Sum, ok := c.lookup(url); ok && validSum(sha256Sum) {
+		blobPath := c.blobPath(sha256Sum)
+		acquireBlob(blobPath)
+		if file, ok := c.fileBySum(sha256Sum); ok {
+			display("Using cached " + url)
+			file.release = func() { c.releaseBlob(blobPath) }
+
+			return file, nil
+		}
+		c.releaseBlob(blobPath)
+	}
+
+	tmpPath, sum, cacheable, err := toTmpFile(ctx, filepath.Join(c.dirPath, blobDirName), url, display, progress, checker, requestOptions)
+	if err != nil {
+		return File{}, err
+	}
+
+	tmpFile := File{Path: tmpPath, SHA256: sum, release: func() { os.Remove(tmpPath) }}
+	if !cacheable {
+		return tmpFile, nil
+	}
+
+	sha256Sum := hex.EncodeToString(sum)
+	blobPath := c.blobPath(sha256Sum)
+	acquireBlob(blobPath)
+	if err = os.Chmod(tmpPath, fileperm.RW); err == nil {
+		err = os.Rename(tmpPath, blobPath)
+	}
+
+	if err != nil {
+		c.releaseBlob(blobPath)
+		display("Unable to store download in cache : " + err.Error())
+
+		return tmpFile, nil
+	}
+
+	if err = c.writeIndex(url, sha256Sum); err != nil {
+		display("Unable to store download in cache : " + err.Error())
+	}
+
+	// pruning is delayed until file release, to not remove it while it is used
+	return File{Path: blobPath, SHA256: sum, release: func() { c.releaseBlob(blobPath) }}, nil
+}
+
+func (c *Cache) Clear() error {
+	if err := os.RemoveAll(filepath.Join(c.dirPath, blobDirName)); err != nil {
+		return err
+	}
+
+	return os.RemoveAll(filepath.Join(c.dirPath, indexDirName))
+}
+
+func (c *Cache) DirPath() string {
+	return c.dirPath
+}
+
+// Get returns content cached for this url (content is verified against its sha256).
+func (c *Cache) Get(url string) ([]byte, bool) {
+	sha256Sum, ok := c.lookup(url)
+	if !ok {
+		return nil, false
+	}
+
+	return c.GetBySum(sha256Sum)
+}
+
+// GetBySum returns cached content with the given sha256 (hex encoded).
+func (c *Cache) GetBySum(sha256Sum string) ([]byte, bool) {
+	if !validSum(sha256Sum) {
+		return nil, false
+	}
+
+	blobPath := c.blobPath(sha256Sum)
+	data, err := os.ReadFile(blobPath)
+	if err != nil {
+		return nil, false
+	}
+
+	if hexSum(data) != sha256Sum {
+		os.Remove(blobPath) // corrupted entry
+
+		return nil, false
+	}
+
+	now := time.Now()
+	os.Chtimes(blobPath, now, now) //nolint // best effort, only used to order pruning
+
+	return data, true
+}
+
+func (c *Cache) List() ([]CacheEntry, error) {
+	blobEntries, err := os.ReadDir(filepath.Join(c.dirPath, blobDirName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	entries := make([]CacheEntry, 0, len(blobEntries))
+	entryIndexes := make(map[string]int, len(blobEntries))
+	for _, blobEntry := range blobEntries {
+		if strings.HasPrefix(blobEntry.Name(), tmpPrefix) {
+			continue
+		}
+
+		info, err := blobEntry.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+
+		entryIndexes[blobEntry.Name()] = len(entries)
+		entries = append(entries, CacheEntry{LastUse: info.ModTime(), SHA256: blobEntry.Name(), Size: info.Size()})
+	}
+
+	indexes, err := c.readIndexes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range indexes {
+		if entryIndex, ok := entryIndexes[index.SHA256]; ok {
+			entries[entryIndex].URLs = append(entries[entryIndex].URLs, index.URL)
+		}
+	}
+
+	slices.SortFunc(entries, func(a CacheEntry, b CacheEntry) int {
+		return b.LastUse.Compare(a.LastUse)
+	})
+
+	return entries, nil
+}
+
+func (c *Cache) MaxSize() int64 {
+	return c.maxSize
+}
+
+// Prune removes least recently used entries until cache size is under maxSize (no limit when 0 or less),
+// and URL indexes without content. Returns removed entries.
+func (c *Cache) Prune(maxSize int64) ([]CacheEntry, error) {
+	entries, err := c.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var totalSize int64
+	for _, entry := range entries {
+		totalSize += entry.Size
+	}
+
+	var removed []CacheEntry
+	kept := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		kept[entry.SHA256] = struct{}{}
+	}
+
+	// entries are sorted by descending last use
+	for i := len(entries) - 1; i >= 0 && maxSize > 0 && totalSize > maxSize; i-- {
+		entry := entries[i]
+		if blobInUse(c.blobPath(entry.SHA256)) {
+			continue
+		}
+
+		if err = os.Remove(c.blobPath(entry.SHA256)); err != nil {
+			return removed, err
+		}
+
+		delete(kept, entry.SHA256)
+		removed = append(removed, entry)
+		totalSize -= entry.Size
+	}
+
+	indexDirPath := filepath.Join(c.dirPath, indexDirName)
+	indexEntries, err := os.ReadDir(indexDirPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return removed, nil
+		}
+
+		return removed, err
+	}
+
+	for _, indexEntry := range indexEntries {
+		if strings.HasPrefix(indexEntry.Name(), tmpPrefix) {
+			continue
+		}
+
+		indexPath := filepath.Join(indexDirPath, indexEntry.Name())
+		index, err := readIndex(indexPath)
+		if _, ok := kept[index.SHA256]; err != nil || !ok {
+			if err = os.Remove(indexPath); err != nil {
+				return removed, err
+			}
+		}
+	}
+
+	return removed, nil
+}
+
+// Put stores data in cache (associated to url) and prune cache when over its size limit.
+func (c *Cache) Put(url string, data []byte) error {
+	sha256Sum := hexSum(data)
+	if err := writeFileAtomic(c.blobPath(sha256Sum), data); err != nil {
+		return err
+	}
+
+	if err := c.writeIndex(url, sha256Sum); err != nil {
+		return err
+	}
+
+	c.autoPrune()
+
+	return nil
+}
+
+func (c *Cache) autoPrune() {
+	if c.maxSize > 0 {
+		c.Prune(c.maxSize) //nolint // best effort, an explicit prune can be done later
+	}
+}
+
+// decrement use count of blobPath then prune cache (blobs still in use are kept).
+func (c *Cache) releaseBlob(blobPath string) {
+	blobsInUse.Lock()
+	if blobsInUse.counts[blobPath]--; blobsInUse.counts[blobPath] <= 0 {
+		delete(blobsInUse.counts, blobPath)
+	}
+	blobsInUse.Unlock()
+
+	c.autoPrune()
+}
+
+func (c *Cache) blobPath(sha256Sum string) string {
+	return filepath.Join(c.dirPath, blobDirName, sha256Sum)
+}
+
+func (c *Cache) fileBySum(sha256Sum string) (File, bool) {
+	if !validSum(sha256Sum) {
+		return File{}, false
+	}
+
+	blobPath := c.blobPath(sha256Sum)
+	sum, err := sha256check.File(blobPath)
+	if err != nil {
+		return File{}, false
+	}
+
+	if hex.EncodeToString(sum) != sha256Sum {
+		os.Remove(blobPath) // corrupted entry
+
+		return File{}, false
+	}
+
+	now := time.Now()
+	os.Chtimes(blobPath, now, now) //nolint // best effort, only used to order pruning
+
+	return File{Path: blobPath, SHA256: sum}, true
+}
+
+func (c *Cache) indexPath(url string) string {
+	return filepath.Join(c.dirPath, indexDirName, hexSum([]byte(url))+".json")
+}
+
+// return the sha256 of content cached for this url.
+func (c *Cache) lookup(url string) (string, bool) {
+	index, err := readIndex(c.indexPath(url))
+	if err != nil || index.URL != url {
+		return "", false
+	}
+
+	return index.SHA256, true
+}
+
+func (c *Cache) readIndexes() ([]cacheIndex, error) {
+	indexDirPath := filepath.Join(c.dirPath, indexDirName)
+	indexEntries, err := os.ReadDir(indexDirPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	indexes := make([]cacheIndex, 0, len(indexEntries))
+	for _, indexEntry := range indexEntries {
+		if index, err := readIndex(filepath.Join(indexDirPath, indexEntry.Name())); err == nil {
+			indexes = append(indexes, index)
+		}
+	}
+
+	return indexes, nil
+}
+
+func (c *Cache) writeIndex(url string, sha256Sum string) error {
+	indexData, err := json.Marshal(cacheIndex{SHA256: sha256Sum, URL: url})
+	if err != nil {
+		return err
+	}
+
+	return writeFileAtomic(c.indexPath(url), indexData)
+}
+
+func acquireBlob(blobPath string) {
+	blobsInUse.Lock()
+	defer blobsInUse.Unlock()
+
+	blobsInUse.counts[blobPath]++
+}
+
+func blobInUse(blobPath string) bool {
+	blobsInUse.Lock()
+	defer blobsInUse.Unlock()
+
+	return blobsInUse.counts[blobPath] > 0
+}
+
+func hexSum(data []byte) string {
+	hashed := sha256.Sum256(data)
+
+	return hex.EncodeToString(hashed[:])
+}
+
+func readIndex(indexPath string) (cacheIndex, error) {
+	var index cacheIndex
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		return index, err
+	}
+
+	err = json.Unmarshal(data, &index)
+
+	return index, err
+}
+
+// avoid path manipulation from altered index files.
+func validSum(sha256Sum string) bool {
+	decoded, err := hex.DecodeString(sha256Sum)
+
+	return err == nil && len(decoded) == sha256.Size
+}
+
+// write in a temporary file then rename it, so concurrent readers never see partial content.
+func writeFileAtomic(filePath string, data []byte) error {
+	dirPath := filepath.Dir(filePath)
+	if err := os.MkdirAll(dirPath, fileperm.RWE); err != nil {
+		return err
+	}
+
+	tmpFile, err := os.CreateTemp(dirPath, tmpPrefix+"*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+
+	_, err = tmpFile.Write(data)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Chmod(tmpPath, fileperm.RW)
+	}
+
+	if err == nil {
+		err = os.Rename(tmpPath, filePath)
+	}
+
+	if err != nil {
+		os.Remove(tmpPath)
+	}
+
+	return err
+}
@@ -0,0 +1,265 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package download_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+func TestCacheBytes(t *testing.T) {
+	t.Parallel()
+
+	var callCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		callCount.Add(1)
+		if request.URL.Path == "/missing" {
+			http.NotFound(writer, request)
+
+			return
+		}
+		writer.Write([]byte("content of " + request.URL.Path)) //nolint
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cache := download.NewCache(t.TempDir(), 0)
+	for range 2 {
+		data, err := cache.Bytes(ctx, server.URL+"/a.zip", download.NoDisplay, download.NoCheck)
+		if err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+		if string(data) != "content of /a.zip" {
+			t.Fatal("Unexpected content :", string(data))
+		}
+	}
+
+	if count := callCount.Load(); count != 1 {
+		t.Error("Expected one network call, get :", count)
+	}
+
+	for range 2 {
+		if _, err := cache.Bytes(ctx, server.URL+"/missing", download.NoDisplay, download.NoCheck); err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+	}
+
+	if count := callCount.Load(); count != 3 {
+		t.Error("Expected not found response to not be cached, get call count :", count)
+	}
+
+	entries, err := cache.List()
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	if len(entries) != 1 || len(entries[0].URLs) != 1 || entries[0].URLs[0] != server.URL+"/a.zip" {
+		t.Error("Unexpected cache entries :", entries)
+	}
+
+	if _, ok := cache.GetBySum(entries[0].SHA256); !ok {
+		t.Error("Expected content to be found by sha256")
+	}
+
+	var nilCache *download.Cache
+	if _, err = nilCache.Bytes(ctx, server.URL+"/a.zip", download.NoDisplay, download.NoCheck); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if count := callCount.Load(); count != 4 {
+		t.Error("Expected nil cache to always download, get call count :", count)
+	}
+}
+
+func TestCachePrune(t *testing.T) {
+	t.Parallel()
+
+	cache := download.NewCache(t.TempDir(), 0)
+	for _, url := range []string{"https://example.com/a", "https://example.com/b", "https://example.com/c"} {
+		if err := cache.Put(url, []byte(url+" content")); err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+	}
+
+	if _, ok := cache.Get("https://example.com/a"); !ok { // mark as recently used
+		t.Fatal("Expected cached content")
+	}
+
+	removed, err := cache.Prune(int64(len("https://example.com/a content")))
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if len(removed) != 2 {
+		t.Error("Unexpected removed entries :", removed)
+	}
+
+	if _, ok := cache.Get("https://example.com/a"); !ok {
+		t.Error("Expected most recently used content to be kept")
+	}
+
+	if _, ok := cache.Get("https://example.com/b"); ok {
+		t.Error("Expected least recently used content to be removed")
+	}
+
+	if err = cache.Clear(); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if entries, err := cache.List(); err != nil || len(entries) != 0 {
+		t.Error("Expected empty cache, get :", entries, err)
+	}
+}
+
+func TestCacheFile(t *testing.T) {
+	t.Parallel()
+
+	var callCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		callCount.Add(1)
+		writer.Write([]byte("content of " + request.URL.Path)) //nolint
+	}))
+	defer server.Close()
+
+	expectedSum := sha256.Sum256([]byte("content of /a.zip"))
+
+	var lastProgress loghelper.Progress
+	progressFunc := func(progress loghelper.Progress) {
+		lastProgress = progress
+	}
+
+	ctx := context.Background()
+	cache := download.NewCache(t.TempDir(), 0)
+	for range 2 {
+		file, err := cache.File(ctx, server.URL+"/a.zip", download.NoDisplay, progressFunc, download.NoCheck)
+		if err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+
+		if !bytes.Equal(file.SHA256, expectedSum[:]) {
+			t.Error("Unexpected sha256 :", file.SHA256)
+		}
+
+		data, err := os.ReadFile(file.Path)
+		if err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+		if string(data) != "content of /a.zip" {
+			t.Error("Unexpected content :", string(data))
+		}
+		file.Release()
+	}
+
+	if count := callCount.Load(); count != 1 {
+		t.Error("Expected one network call, get :", count)
+	}
+
+	if !lastProgress.Done || lastProgress.Received != int64(len("content of /a.zip")) || lastProgress.Total != lastProgress.Received {
+		t.Error("Unexpected last progress event :", lastProgress)
+	}
+
+	var nilCache *download.Cache
+	file, err := nilCache.File(ctx, server.URL+"/a.zip", download.NoDisplay, loghelper.NoProgress, download.NoCheck)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if !bytes.Equal(file.SHA256, expectedSum[:]) {
+		t.Error("Unexpected sha256 :", file.SHA256)
+	}
+
+	file.Release()
+	if _, err = os.Stat(file.Path); !errors.Is(err, fs.ErrNotExist) {
+		t.Error("Expected temporary file to be removed, get :", err)
+	}
+}
+
+func TestCacheFileInUse(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write([]byte("content of " + request.URL.Path)) //nolint
+	}))
+	defer server.Close()
+
+	// cache can not hold both files, like with parallel installations
+	ctx := context.Background()
+	cache := download.NewCache(t.TempDir(), int64(len("content of /a.zip")))
+	fileA, err := cache.File(ctx, server.URL+"/a.zip", download.NoDisplay, loghelper.NoProgress, download.NoCheck)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	fileB, err := cache.File(ctx, server.URL+"/b.zip", download.NoDisplay, loghelper.NoProgress, download.NoCheck)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	fileB.Release()
+
+	if _, err = os.Stat(fileA.Path); err != nil {
+		t.Error("Expected file in use to be kept by pruning, get :", err)
+	}
+
+	fileA.Release()
+	entries, err := cache.List()
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if len(entries) != 1 {
+		t.Error("Expected prune after last release, get :", entries)
+	}
+}
+
+func TestCacheOffline(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write([]byte("content of " + request.URL.Path)) //nolint
+	}))
+	defer server.Close()
+
+	cache := download.NewCache(t.TempDir(), 0)
+	if _, err := cache.Bytes(context.Background(), server.URL+"/a.zip", download.NoDisplay, download.NoCheck); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	offlineCtx := download.WithOffline(context.Background())
+	file, err := cache.File(offlineCtx, server.URL+"/a.zip", download.NoDisplay, loghelper.NoProgress, download.NoCheck)
+	if err != nil {
+		t.Fatal("Unexpected error with cached content :", err)
+	}
+	file.Release()
+
+	_, err = cache.Bytes(offlineCtx, server.URL+"/b.zip", download.NoDisplay, download.NoCheck)
+	var offlineErr download.OfflineError
+	if !errors.Is(err, download.ErrOffline) || !errors.As(err, &offlineErr) || offlineErr.Need != "download of "+server.URL+"/b.zip" {
+		t.Error("Expected offline error, get :", err)
+	}
+}
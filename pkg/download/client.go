@@ -0,0 +1,244 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package download
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultUserAgent = "tenv"
+
+	fileScheme = "file"
+)
+
+// local directories are served like a static HTTP server (with Range support and 404 on missing file).
+var fileTransport = http.NewFileTransport(localFS{}) //nolint
+
+var (
+	ErrLocalFile     = errors.New("file:// URL not allowed for this request")
+	ErrNoCertificate = errors.New("no certificate found in CA file")
+	ErrReadTimeout   = errors.New("no data received before read timeout")
+)
+
+type (
+	clientKey    struct{}
+	localFileKey struct{}
+)
+
+type ClientConfig struct {
+	CAFile         string // PEM file with certificates trusted in addition to system ones
+	CertFile       string // client certificate for mTLS (KeyFile is required too)
+	ConnectTimeout time.Duration
+	KeyFile        string
+	ProxyURL       string        // HTTP(S)_PROXY env vars are used when empty
+	ReadTimeout    time.Duration // maximum wait for response headers and between two body reads
+	UserAgent      string
+}
+
+// NewClient builds an HTTP client from clientConf, zero values keep Go defaults (except the user agent which default to DefaultUserAgent).
+func NewClient(clientConf ClientConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint
+
+	if clientConf.ConnectTimeout > 0 {
+		transport.DialContext = (&net.Dialer{Timeout: clientConf.ConnectTimeout, KeepAlive: 30 * time.Second}).DialContext
+		transport.TLSHandshakeTimeout = clientConf.ConnectTimeout
+	}
+
+	if clientConf.ProxyURL != "" {
+		proxyURL, err := url.Parse(clientConf.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig, err := buildTLSConfig(clientConf)
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	userAgent := clientConf.UserAgent
+	if userAgent == "" {
+		userAgent = DefaultUserAgent
+	}
+
+	var roundTripper http.RoundTripper = transport
+	if clientConf.ReadTimeout > 0 {
+		transport.ResponseHeaderTimeout = clientConf.ReadTimeout
+		roundTripper = readTimeoutTransport{next: roundTripper, timeout: clientConf.ReadTimeout}
+	}
+
+	return &http.Client{Transport: userAgentTransport{next: roundTripper, userAgent: userAgent}}, nil
+}
+
+// WithClient returns a context making downloads done with it use client (http.DefaultClient is used otherwise).
+func WithClient(ctx context.Context, client *http.Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+func buildTLSConfig(clientConf ClientConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if clientConf.CAFile != "" {
+		data, err := os.ReadFile(clientConf.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		certPool, err := x509.SystemCertPool()
+		if err != nil {
+			certPool = x509.NewCertPool()
+		}
+
+		if !certPool.AppendCertsFromPEM(data) {
+			return nil, ErrNoCertificate
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	if clientConf.CertFile != "" || clientConf.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(clientConf.CertFile, clientConf.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// AllowLocalFile is a RequestOption permitting to read a local file for a file:// URL (refused otherwise).
+func AllowLocalFile(request *http.Request) {
+	*request = *request.WithContext(context.WithValue(request.Context(), localFileKey{}, true))
+}
+
+// send request with client from context, or read a local file for allowed file:// URLs (or serve a recorded response).
+func do(ctx context.Context, request *http.Request) (*http.Response, error) {
+	if responses, ok := ctx.Value(replayKey{}).(map[string][]byte); ok {
+		return replay(request, responses), nil
+	}
+
+	if request.URL.Scheme == fileScheme {
+		if allowed, _ := request.Context().Value(localFileKey{}).(bool); !allowed {
+			return nil, fmt.Errorf("%w : %s", ErrLocalFile, request.URL)
+		}
+
+		return fileTransport.RoundTrip(request)
+	}
+
+	return clientFrom(ctx).Do(request)
+}
+
+func isLocal(urlValue string) bool {
+	return strings.HasPrefix(urlValue, fileScheme+"://")
+}
+
+func clientFrom(ctx context.Context) *http.Client {
+	if client, ok := ctx.Value(clientKey{}).(*http.Client); ok {
+		return client
+	}
+
+	return http.DefaultClient
+}
+
+type readTimeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t readTimeoutTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancelCause(request.Context())
+	response, err := t.next.RoundTrip(request.WithContext(ctx))
+	if err != nil {
+		cancel(nil)
+
+		return nil, err
+	}
+
+	body := &timeoutBody{ReadCloser: response.Body, cancel: cancel, ctx: ctx, timeout: t.timeout}
+	body.timer = time.AfterFunc(t.timeout, func() { cancel(ErrReadTimeout) })
+	response.Body = body
+
+	return response, nil
+}
+
+// cancel the request when no data is received during timeout.
+type timeoutBody struct {
+	io.ReadCloser
+	cancel  context.CancelCauseFunc
+	ctx     context.Context //nolint
+	timeout time.Duration
+	timer   *time.Timer
+}
+
+func (b *timeoutBody) Close() error {
+	b.timer.Stop()
+	err := b.ReadCloser.Close()
+	b.cancel(nil)
+
+	return err
+}
+
+func (b *timeoutBody) Read(buffer []byte) (int, error) {
+	n, err := b.ReadCloser.Read(buffer)
+	if err != nil && errors.Is(context.Cause(b.ctx), ErrReadTimeout) {
+		return n, ErrReadTimeout
+	}
+	b.timer.Reset(b.timeout)
+
+	return n, err
+}
+
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+func (t userAgentTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.Header.Get("User-Agent") == "" {
+		request = request.Clone(request.Context())
+		request.Header.Set("User-Agent", t.userAgent)
+	}
+
+	return t.next.RoundTrip(request)
+}
+
+type localFS struct{}
+
+func (localFS) Open(name string) (http.File, error) {
+	if runtime.GOOS == "windows" { // file:///C:/path
+		name = strings.TrimPrefix(name, "/")
+	}
+
+	return os.Open(filepath.FromSlash(name))
+}
@@ -0,0 +1,142 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package download_test
+
+import (
+	"context"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tofuutils/tenv/v4/pkg/download"
+)
+
+func TestClientCAFile(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write([]byte(request.UserAgent())) //nolint
+	}))
+	defer server.Close()
+
+	caPath := filepath.Join(t.TempDir(), "ca.pem")
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caPath, caData, 0o600); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	client, err := download.NewClient(download.ClientConfig{CAFile: caPath, UserAgent: "tenv-test"})
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	noRetryCtx := download.WithRetryPolicy(context.Background(), download.RetryPolicy{Attempts: 1})
+	data, err := download.Bytes(download.WithClient(noRetryCtx, client), server.URL, download.NoDisplay, download.NoCheck)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if string(data) != "tenv-test" {
+		t.Error("Unexpected user agent, get :", string(data))
+	}
+
+	if _, err = download.Bytes(noRetryCtx, server.URL, download.NoDisplay, download.NoCheck); err == nil {
+		t.Error("Expected certificate error without CA file")
+	}
+
+	if err = os.WriteFile(caPath, []byte("no pem content"), 0o600); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if _, err = download.NewClient(download.ClientConfig{CAFile: caPath}); !errors.Is(err, download.ErrNoCertificate) {
+		t.Error("Expected no certificate error, get :", err)
+	}
+}
+
+func TestClientReadTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write([]byte("start")) //nolint
+		writer.(http.Flusher).Flush()
+
+		select { // stall until client gives up
+		case <-request.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	client, err := download.NewClient(download.ClientConfig{ReadTimeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	ctx := download.WithClient(download.WithRetryPolicy(context.Background(), download.RetryPolicy{Attempts: 1}), client)
+	if _, err = download.Bytes(ctx, server.URL, download.NoDisplay, download.NoCheck); !errors.Is(err, download.ErrReadTimeout) {
+		t.Error("Expected read timeout error, get :", err)
+	}
+}
+
+func TestClientFileURL(t *testing.T) {
+	t.Parallel()
+
+	dirPath := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dirPath, "index.json"), []byte("local content"), 0o600); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	baseURL := "file://" + filepath.ToSlash(dirPath)
+	if !strings.HasPrefix(dirPath, "/") { // windows drive letter
+		baseURL = "file:///" + filepath.ToSlash(dirPath)
+	}
+
+	ctx := download.WithRetryPolicy(context.Background(), download.RetryPolicy{Attempts: 1})
+	if _, err := download.Bytes(ctx, baseURL+"/index.json", download.NoDisplay, download.NoCheck); !errors.Is(err, download.ErrLocalFile) {
+		t.Error("Should fail on file URL without AllowLocalFile option, get :", err)
+	}
+
+	// local files stay readable in offline mode
+	ctx = download.WithOffline(ctx)
+	data, err := download.Bytes(ctx, baseURL+"/index.json", download.NoDisplay, download.NoCheck, download.AllowLocalFile)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if string(data) != "local content" {
+		t.Error("Unexpected result, get :", string(data))
+	}
+
+	checker := func(response *http.Response) error {
+		if response.StatusCode != http.StatusNotFound {
+			return errors.New("unexpected status " + response.Status)
+		}
+
+		return nil
+	}
+	if _, err = download.Bytes(ctx, baseURL+"/missing.json", download.NoDisplay, checker, download.AllowLocalFile); err != nil {
+		t.Error("Should return not found status on missing file, get :", err)
+	}
+}
@@ -21,11 +21,39 @@ package download
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 )
 
+var ErrOffline = errors.New("network access disabled by offline mode")
+
+type offlineKey struct{}
+
+// OfflineError describes a network access refused in offline mode.
+type OfflineError struct {
+	Need string
+}
+
+func (e OfflineError) Error() string {
+	return "offline mode, network access needed for " + e.Need
+}
+
+func (e OfflineError) Unwrap() error {
+	return ErrOffline
+}
+
+// IsNetworkError reports errors coming from an unreachable remote (offline mode, connection failure or timeout)
+// rather than from a received response.
+func IsNetworkError(err error) bool {
+	var netErr net.Error
+
+	return errors.Is(err, ErrOffline) || errors.Is(err, ErrReadTimeout) || errors.Is(err, context.DeadlineExceeded) || errors.As(err, &netErr)
+}
+
 type RequestOption = func(*http.Request)
 
 type ResponseChecker = func(*http.Response) error
@@ -47,26 +75,29 @@ func ApplyURLTransformer(urlTransformer URLTransformer, baseURLs ...string) ([]s
 func Bytes(ctx context.Context, url string, display func(string), checker ResponseChecker, requestOptions ...RequestOption) ([]byte, error) {
 	display("Downloading " + url)
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
-	if err != nil {
-		return nil, err
-	}
+	retrier := newRetrier(ctx, display)
+	for {
+		response, err := get(ctx, retrier, url, checker, requestOptions, 0)
+		if err != nil {
+			return nil, err
+		}
 
-	for _, option := range requestOptions {
-		option(request)
-	}
+		data, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		if err == nil && response.StatusCode == http.StatusOK {
+			Record(ctx, url, data)
+		}
 
-	response, err := http.DefaultClient.Do(request)
-	if err != nil {
-		return nil, err
+		if err == nil || !retrier.next(ctx, err.Error(), nil) {
+			return data, err
+		}
 	}
-	defer response.Body.Close()
+}
 
-	if err = checker(response); err != nil {
-		return nil, err
-	}
+func IsOffline(ctx context.Context) bool {
+	offline, _ := ctx.Value(offlineKey{}).(bool)
 
-	return io.ReadAll(response.Body)
+	return offline
 }
 
 func JSON(ctx context.Context, url string, display func(string), checker ResponseChecker, requestOptions ...RequestOption) (any, error) {
@@ -100,6 +131,71 @@ func NewURLTransformer(prevBaseURL string, baseURL string) URLTransformer {
 	}
 }
 
+// send request (with a Range header when offset is positive), retrying on network error or retryable status code.
+func get(ctx context.Context, retrier *retrier, url string, checker ResponseChecker, requestOptions []RequestOption, offset int64) (*http.Response, error) {
+	if IsOffline(ctx) && !isLocal(url) && !IsReplay(ctx) {
+		return nil, OfflineError{Need: "download of " + url}
+	}
+
+	var response *http.Response
+	for {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, option := range requestOptions {
+			option(request)
+		}
+
+		if offset > 0 {
+			request.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+		}
+
+		response, err = do(ctx, request)
+		if err != nil {
+			if !errors.Is(err, ErrLocalFile) && retrier.next(ctx, err.Error(), nil) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		// whatever the status code, a reached rate limit is retried after its reset
+		if reset, limited := RateLimitReset(response); limited {
+			if !retrier.waitRateLimit(ctx, reset, response) {
+				break // no retry before reset, response is handled by checker
+			}
+
+			continue
+		}
+
+		// when no attempts remain, the last response is handled by checker
+		if !retrier.retryableStatus(response) || !retrier.next(ctx, response.Status, response) {
+			break
+		}
+	}
+
+	if err := ctx.Err(); err != nil { // interrupted while waiting
+		response.Body.Close()
+
+		return nil, err
+	}
+
+	if err := checker(response); err != nil {
+		response.Body.Close()
+
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// WithOffline returns a context making downloads done with it fail with an OfflineError (cached contents are still usable).
+func WithOffline(ctx context.Context) context.Context {
+	return context.WithValue(ctx, offlineKey{}, true)
+}
+
 func WithBasicAuth(username string, password string) RequestOption {
 	return func(r *http.Request) {
 		r.SetBasicAuth(username, password)
@@ -19,11 +19,40 @@
 package download_test
 
 import (
+	"errors"
+	"fmt"
+	"net/url"
+	"syscall"
 	"testing"
 
 	"github.com/tofuutils/tenv/v4/pkg/download"
 )
 
+func TestIsNetworkError(t *testing.T) {
+	t.Parallel()
+
+	connErr := &url.Error{Op: "Get", URL: "https://github.com", Err: syscall.ECONNREFUSED}
+	tests := map[string]struct {
+		err  error
+		want bool
+	}{
+		"Offline":    {err: fmt.Errorf("wrapped : %w", download.OfflineError{Need: "download"}), want: true},
+		"Connection": {err: connErr, want: true},
+		"Timeout":    {err: download.ErrReadTimeout, want: true},
+		"Other":      {err: errors.New("checksum mismatch"), want: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := download.IsNetworkError(test.err); got != test.want {
+				t.Error("Unmatching results, get :", got)
+			}
+		})
+	}
+}
+
 func TestURLTransformer(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,150 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/tofuutils/tenv/v4/pkg/fileperm"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+// File is a downloaded content stored on disk, its sha256 is computed while writing it.
+type File struct {
+	Path    string
+	SHA256  []byte
+	release func()
+}
+
+// ToFile streams downloaded content into a temporary file (call Release to remove it).
+func ToFile(ctx context.Context, url string, display func(string), progress func(loghelper.Progress), checker ResponseChecker, requestOptions ...RequestOption) (File, error) {
+	tmpPath, sum, _, err := toTmpFile(ctx, "", url, display, progress, checker, requestOptions)
+	if err != nil {
+		return File{}, err
+	}
+
+	return File{Path: tmpPath, SHA256: sum, release: func() { os.Remove(tmpPath) }}, nil
+}
+
+// CopyTo copies the file content (used when the downloaded file is directly the binary).
+func (f File) CopyTo(destPath string, perm os.FileMode) error {
+	srcFile, err := os.Open(f.Path)
+	if err != nil {
+		return err
+	}
+	defer srcFile.Close()
+
+	destFile, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(destFile, srcFile)
+	if closeErr := destFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// Release must be called once the file is no longer used.
+func (f File) Release() {
+	if f.release != nil {
+		f.release()
+	}
+}
+
+// stream response body in a new temporary file in dirPath (default temporary directory when empty),
+// hashing it on the fly. Interrupted transfers are resumed with a Range request when the server supports it.
+// Returned boolean indicates a successful status code (content can be cached).
+func toTmpFile(ctx context.Context, dirPath string, url string, display func(string), progress func(loghelper.Progress), checker ResponseChecker, requestOptions []RequestOption) (string, []byte, bool, error) {
+	display("Downloading " + url)
+
+	if dirPath != "" {
+		if err := os.MkdirAll(dirPath, fileperm.RWE); err != nil {
+			return "", nil, false, err
+		}
+	}
+
+	tmpFile, err := os.CreateTemp(dirPath, tmpPrefix+"*")
+	if err != nil {
+		return "", nil, false, err
+	}
+	tmpPath := tmpFile.Name()
+
+	hasher := sha256.New()
+	reader := newProgressReader(url, progress)
+	retrier := newRetrier(ctx, display)
+	var received int64
+	var response *http.Response
+	for {
+		if response, err = get(ctx, retrier, url, checker, requestOptions, received); err != nil {
+			break
+		}
+
+		if received != 0 && !resumed(response, received) {
+			// server ignored the Range header, restart from scratch
+			if _, err = tmpFile.Seek(0, io.SeekStart); err == nil {
+				err = tmpFile.Truncate(0)
+			}
+			if err != nil {
+				response.Body.Close()
+
+				break
+			}
+
+			hasher.Reset()
+			received = 0
+		}
+		reader.resume(response.Body, received, response.ContentLength)
+
+		var written int64
+		written, err = io.Copy(io.MultiWriter(tmpFile, hasher), reader)
+		response.Body.Close()
+		received += written
+		if err == nil || !retrier.next(ctx, err.Error(), nil) {
+			break
+		}
+		display("Resuming download of " + url + " from byte " + strconv.FormatInt(received, 10))
+	}
+
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(tmpPath)
+
+		return "", nil, false, err
+	}
+
+	reader.done()
+
+	return tmpPath, hasher.Sum(nil), response.StatusCode == http.StatusOK || response.StatusCode == http.StatusPartialContent, nil
+}
+
+func resumed(response *http.Response, offset int64) bool {
+	return response.StatusCode == http.StatusPartialContent && strings.HasPrefix(response.Header.Get("Content-Range"), "bytes "+strconv.FormatInt(offset, 10)+"-")
+}
@@ -0,0 +1,82 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package download
+
+import (
+	"io"
+	"time"
+
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+const progressInterval = 200 * time.Millisecond
+
+type progressReader struct {
+	lastSent     time.Time
+	progress     loghelper.Progress
+	progressFunc func(loghelper.Progress)
+	reader       io.Reader
+	start        time.Time
+}
+
+func newProgressReader(url string, progressFunc func(loghelper.Progress)) *progressReader {
+	now := time.Now()
+
+	return &progressReader{
+		lastSent:     now,
+		progress:     loghelper.Progress{URL: url},
+		progressFunc: progressFunc,
+		start:        now,
+	}
+}
+
+func (pr *progressReader) Read(buffer []byte) (int, error) {
+	n, err := pr.reader.Read(buffer)
+	pr.progress.Received += int64(n)
+
+	if now := time.Now(); now.Sub(pr.lastSent) >= progressInterval {
+		pr.lastSent = now
+		pr.send(now)
+	}
+
+	return n, err
+}
+
+func (pr *progressReader) done() {
+	pr.progress.Done = true
+	pr.send(time.Now())
+}
+
+// read from reader, received bytes being already handled (contentLength is -1 when unknown).
+func (pr *progressReader) resume(reader io.Reader, received int64, contentLength int64) {
+	pr.reader = reader
+	pr.progress.Received = received
+	pr.progress.Total = loghelper.UnknownTotal
+	if contentLength >= 0 {
+		pr.progress.Total = received + contentLength
+	}
+}
+
+func (pr *progressReader) send(now time.Time) {
+	if elapsed := now.Sub(pr.start).Seconds(); elapsed > 0 {
+		pr.progress.Rate = float64(pr.progress.Received) / elapsed
+	}
+
+	pr.progressFunc(pr.progress)
+}
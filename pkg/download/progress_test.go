@@ -0,0 +1,80 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package download
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+func TestProgressReader(t *testing.T) {
+	t.Parallel()
+
+	var events []loghelper.Progress
+	reader := newProgressReader("http://localhost/a.zip", func(progress loghelper.Progress) {
+		events = append(events, progress)
+	})
+
+	// resumed download : 4 bytes already received, 6 remaining
+	reader.resume(strings.NewReader("abcdef"), 4, 6)
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if string(data) != "abcdef" {
+		t.Error("Unmatching results, get :", string(data))
+	}
+
+	if len(events) != 0 { // throttled
+		t.Error("Unexpected progress events before interval, get :", events)
+	}
+
+	reader.done()
+	if len(events) != 1 {
+		t.Fatal("Expected one final event, get :", events)
+	}
+
+	last := events[0]
+	if !last.Done || last.Received != 10 || last.Total != 10 || last.URL != "http://localhost/a.zip" || last.Rate <= 0 {
+		t.Error("Unmatching final event, get :", last)
+	}
+}
+
+func TestProgressReaderUnknownTotal(t *testing.T) {
+	t.Parallel()
+
+	var last loghelper.Progress
+	reader := newProgressReader("http://localhost/a.zip", func(progress loghelper.Progress) {
+		last = progress
+	})
+
+	reader.resume(strings.NewReader("abc"), 0, -1)
+	if _, err := io.ReadAll(reader); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	reader.done()
+
+	if last.Received != 3 || last.Total != loghelper.UnknownTotal {
+		t.Error("Unmatching final event, get :", last)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package download
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"maps"
+	"net/http"
+	"sync"
+)
+
+type (
+	recorderKey struct{}
+	replayKey   struct{}
+)
+
+// Recorder keeps bodies of successful downloads done with a context from WithRecorder (including cached ones),
+// to serve them again later with WithReplay.
+type Recorder struct {
+	mutex     sync.Mutex
+	responses map[string][]byte
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{responses: map[string][]byte{}}
+}
+
+// Responses returns recorded bodies by URL.
+func (r *Recorder) Responses() map[string][]byte {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return maps.Clone(r.responses)
+}
+
+// IsReplay reports whether downloads done with ctx are served from recorded responses (caches must then be ignored).
+func IsReplay(ctx context.Context) bool {
+	_, ok := ctx.Value(replayKey{}).(map[string][]byte)
+
+	return ok
+}
+
+// Record adds data obtained for url to the Recorder of ctx (when there is one), caches call it when they serve a content.
+func Record(ctx context.Context, url string, data []byte) {
+	if recorder, ok := ctx.Value(recorderKey{}).(*Recorder); ok {
+		recorder.mutex.Lock()
+		recorder.responses[url] = data
+		recorder.mutex.Unlock()
+	}
+}
+
+func WithRecorder(ctx context.Context, recorder *Recorder) context.Context {
+	return context.WithValue(ctx, recorderKey{}, recorder)
+}
+
+// WithReplay returns a context making downloads done with it served from responses (by URL) without network access,
+// URL not found in responses get a 404 status.
+func WithReplay(ctx context.Context, responses map[string][]byte) context.Context {
+	return context.WithValue(ctx, replayKey{}, responses)
+}
+
+func replay(request *http.Request, responses map[string][]byte) *http.Response {
+	data, ok := responses[request.URL.String()]
+	statusCode := http.StatusOK
+	if !ok {
+		statusCode = http.StatusNotFound
+	}
+
+	return &http.Response{
+		Body: io.NopCloser(bytes.NewReader(data)), ContentLength: int64(len(data)), Header: http.Header{},
+		Request: request, Status: http.StatusText(statusCode), StatusCode: statusCode,
+	}
+}
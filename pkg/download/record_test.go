@@ -0,0 +1,84 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package download_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/pkg/download"
+)
+
+var errStatus = errors.New("unexpected status")
+
+func TestRecordReplay(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/sums" {
+			writer.Write([]byte("0123  tool.zip\n")) //nolint
+
+			return
+		}
+
+		writer.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	sumsURL, missingURL := server.URL+"/sums", server.URL+"/missing"
+	cache := download.NewCache(t.TempDir(), 0)
+	if _, err := cache.Bytes(t.Context(), sumsURL, download.NoDisplay, download.NoCheck); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	// served from cache, still recorded
+	recorder := download.NewRecorder()
+	ctx := download.WithRecorder(t.Context(), recorder)
+	for _, url := range []string{sumsURL, missingURL} {
+		if _, err := cache.Bytes(ctx, url, download.NoDisplay, download.NoCheck); err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+	}
+
+	responses := recorder.Responses()
+	if len(responses) != 1 || string(responses[sumsURL]) != "0123  tool.zip\n" {
+		t.Fatal("Unmatching results, get :", responses)
+	}
+
+	server.Close()
+
+	ctx = download.WithReplay(download.WithOffline(t.Context()), responses)
+	data, err := cache.Bytes(ctx, sumsURL, download.NoDisplay, download.NoCheck)
+	if err != nil || string(data) != "0123  tool.zip\n" {
+		t.Error("Unmatching results, get :", string(data), err)
+	}
+
+	checkFound := func(response *http.Response) error {
+		if response.StatusCode != http.StatusOK {
+			return errStatus
+		}
+
+		return nil
+	}
+	if _, err = download.Bytes(ctx, missingURL, download.NoDisplay, checkFound); !errors.Is(err, errStatus) {
+		t.Error("Should fail on not recorded response, get :", err)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package download
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	rateLimitRemainingHeader = "X-Ratelimit-Remaining"
+	rateLimitResetHeader     = "X-Ratelimit-Reset"
+	retryAfterHeader         = "Retry-After"
+
+	defaultRateLimitWait = time.Minute
+)
+
+var DefaultRetryPolicy = RetryPolicy{ //nolint
+	Attempts:   3,
+	Backoff:    time.Second,
+	MaxBackoff: 30 * time.Second,
+	RetryableStatus: []int{
+		http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusInternalServerError,
+		http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout,
+	},
+}
+
+type retryPolicyKey struct{}
+
+type RetryPolicy struct {
+	Attempts         int           // total number of attempts, 1 or less disable retries
+	Backoff          time.Duration // wait before first retry, doubled for each following retry
+	MaxBackoff       time.Duration
+	MaxRateLimitWait time.Duration // wait for rate limit reset only when it is sooner (0 disable waiting)
+	RetryableStatus  []int
+}
+
+// WithRetryPolicy returns a context making downloads done with it follow policy (DefaultRetryPolicy is used otherwise).
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+type retrier struct {
+	attempt        int
+	display        func(string)
+	policy         RetryPolicy
+	rateLimitWaits int
+}
+
+func newRetrier(ctx context.Context, display func(string)) *retrier {
+	policy, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy)
+	if !ok {
+		policy = DefaultRetryPolicy
+	}
+
+	return &retrier{attempt: 1, display: display, policy: policy}
+}
+
+func (r *retrier) retryableStatus(response *http.Response) bool {
+	return slices.Contains(r.policy.RetryableStatus, response.StatusCode)
+}
+
+// wait before next attempt, return false when no attempts remain or context is done.
+// response is optional, it allows to use a Retry-After header and its body is closed before waiting.
+func (r *retrier) next(ctx context.Context, reason string, response *http.Response) bool {
+	if r.attempt >= r.policy.Attempts || ctx.Err() != nil {
+		return false
+	}
+
+	wait := r.policy.Backoff << (r.attempt - 1)
+	if wait > r.policy.MaxBackoff || wait <= 0 { // also protect against shift overflow
+		wait = r.policy.MaxBackoff
+	}
+
+	if retryAfter := parseRetryAfter(response); retryAfter > wait && retryAfter <= r.policy.MaxBackoff {
+		wait = retryAfter
+	}
+
+	if response != nil {
+		response.Body.Close()
+	}
+
+	r.attempt++
+	r.display("Download failed (" + reason + "), attempt " + strconv.Itoa(r.attempt) + "/" + strconv.Itoa(r.policy.Attempts) + " in " + wait.String())
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// wait until reset when it is allowed by policy (response body is closed before waiting) : a close reset (within MaxBackoff)
+// consumes an attempt like other retries, a later one is waited only when it is sooner than MaxRateLimitWait.
+// Return false otherwise or when context is done.
+func (r *retrier) waitRateLimit(ctx context.Context, reset time.Time, response *http.Response) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	wait := max(time.Until(reset), 0)
+	if wait <= r.policy.MaxBackoff {
+		if r.attempt >= r.policy.Attempts {
+			return false
+		}
+		r.attempt++
+	} else {
+		if wait > r.policy.MaxRateLimitWait || r.rateLimitWaits >= r.policy.Attempts {
+			return false
+		}
+		r.rateLimitWaits++
+	}
+	response.Body.Close()
+
+	r.display("Rate limit reached, waiting until " + reset.Local().Format(time.TimeOnly))
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// RateLimitReset returns the rate limit reset time when response indicates a reached rate limit
+// (based on Retry-After or X-RateLimit-Remaining and X-RateLimit-Reset headers).
+func RateLimitReset(response *http.Response) (time.Time, bool) {
+	if response.StatusCode < http.StatusBadRequest {
+		return time.Time{}, false
+	}
+
+	if retryAfter := parseRetryAfter(response); retryAfter > 0 {
+		return time.Now().Add(retryAfter), true
+	}
+
+	if response.Header.Get(rateLimitRemainingHeader) != "0" {
+		if response.StatusCode == http.StatusTooManyRequests {
+			return time.Now().Add(defaultRateLimitWait), true
+		}
+
+		return time.Time{}, false
+	}
+
+	if resetEpoch, err := strconv.ParseInt(response.Header.Get(rateLimitResetHeader), 10, 64); err == nil {
+		return time.Unix(resetEpoch, 0), true
+	}
+
+	return time.Now().Add(defaultRateLimitWait), true
+}
+
+func parseRetryAfter(response *http.Response) time.Duration {
+	if response == nil {
+		return 0
+	}
+
+	value := response.Header.Get(retryAfterHeader)
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+
+	return 0
+}
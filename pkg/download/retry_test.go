@@ -0,0 +1,212 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package download_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+var testRetryPolicy = download.RetryPolicy{ //nolint
+	Attempts: 3, Backoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond, RetryableStatus: []int{http.StatusServiceUnavailable},
+}
+
+func TestRetryStatus(t *testing.T) {
+	t.Parallel()
+
+	var callCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if callCount.Add(1) < 3 {
+			writer.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+		writer.Write([]byte("content")) //nolint
+	}))
+	defer server.Close()
+
+	ctx := download.WithRetryPolicy(context.Background(), testRetryPolicy)
+	data, err := download.Bytes(ctx, server.URL, download.NoDisplay, download.NoCheck)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if string(data) != "content" || callCount.Load() != 3 {
+		t.Error("Unexpected result, get :", string(data), callCount.Load())
+	}
+
+	callCount.Store(0)
+	noRetryPolicy := testRetryPolicy
+	noRetryPolicy.Attempts = 1
+	ctx = download.WithRetryPolicy(context.Background(), noRetryPolicy)
+	_, err = download.Bytes(ctx, server.URL, download.NoDisplay, func(response *http.Response) error {
+		if response.StatusCode != http.StatusServiceUnavailable {
+			t.Error("Unexpected status code, get :", response.StatusCode)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if count := callCount.Load(); count != 1 {
+		t.Error("Expected no retry, get call count :", count)
+	}
+}
+
+func TestRetryResume(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(strings.Repeat("archive content ", 4096))
+	var rangeRequested atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.Header.Get("Range") == "" {
+			// send half of the content then break the connection
+			writer.Header().Set("Content-Length", strconv.Itoa(len(content)))
+			writer.Write(content[:len(content)/2]) //nolint
+			writer.(http.Flusher).Flush()
+
+			panic(http.ErrAbortHandler)
+		}
+
+		rangeRequested.Store(true)
+		http.ServeContent(writer, request, "", time.Time{}, bytes.NewReader(content))
+	}))
+	defer server.Close()
+
+	ctx := download.WithRetryPolicy(context.Background(), testRetryPolicy)
+	file, err := download.ToFile(ctx, server.URL, download.NoDisplay, loghelper.NoProgress, download.NoCheck)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	defer file.Release()
+
+	if !rangeRequested.Load() {
+		t.Error("Expected a Range request to resume download")
+	}
+
+	data, err := os.ReadFile(file.Path)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	expectedSum := sha256.Sum256(content)
+	if !bytes.Equal(data, content) || !bytes.Equal(file.SHA256, expectedSum[:]) {
+		t.Error("Unexpected resumed content, get length :", len(data))
+	}
+}
+
+func TestRetryRateLimit(t *testing.T) {
+	t.Parallel()
+
+	var callCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if callCount.Add(1) == 1 {
+			writer.Header().Set("X-Ratelimit-Remaining", "0")
+			writer.Header().Set("X-Ratelimit-Reset", strconv.FormatInt(time.Now().Add(2*time.Second).Unix(), 10))
+			writer.WriteHeader(http.StatusForbidden)
+
+			return
+		}
+		writer.Write([]byte("content")) //nolint
+	}))
+	defer server.Close()
+
+	var limited bool
+	checker := func(response *http.Response) error {
+		_, limited = download.RateLimitReset(response)
+
+		return nil
+	}
+
+	if _, err := download.Bytes(download.WithRetryPolicy(context.Background(), testRetryPolicy), server.URL, download.NoDisplay, checker); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if !limited || callCount.Load() != 1 {
+		t.Error("Expected rate limited response without waiting, get call count :", callCount.Load())
+	}
+
+	callCount.Store(0)
+	waitPolicy := testRetryPolicy
+	waitPolicy.MaxRateLimitWait = 3 * time.Second
+	data, err := download.Bytes(download.WithRetryPolicy(context.Background(), waitPolicy), server.URL, download.NoDisplay, checker)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if limited || string(data) != "content" || callCount.Load() != 2 {
+		t.Error("Expected success after waiting rate limit reset, get :", string(data), callCount.Load())
+	}
+}
+
+func TestRetryRateLimitDefaultPolicy(t *testing.T) {
+	t.Parallel()
+
+	waitPolicy := download.DefaultRetryPolicy
+	waitPolicy.MaxRateLimitWait = time.Hour
+
+	tests := []struct {
+		name   string
+		policy download.RetryPolicy
+	}{
+		{name: "Default", policy: download.DefaultRetryPolicy},
+		{name: "MaxRateLimitWait", policy: waitPolicy},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var callCount atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+				if callCount.Add(1) == 1 { // GitHub secondary rate limit
+					writer.Header().Set("Retry-After", "1")
+					writer.WriteHeader(http.StatusForbidden)
+
+					return
+				}
+				writer.Write([]byte("content")) //nolint
+			}))
+			defer server.Close()
+
+			data, err := download.Bytes(download.WithRetryPolicy(context.Background(), test.policy), server.URL, download.NoDisplay, download.NoCheck)
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if string(data) != "content" || callCount.Load() != 2 {
+				t.Error("Expected success after waiting rate limit reset, get :", string(data), callCount.Load())
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package githubcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/tofuutils/tenv/v4/pkg/fileperm"
+)
+
+// Cache stores GitHub API responses with their ETag : fresh entries (younger than ttl) are used
+// without network call, older ones are revalidated with a conditional request (a 304 response
+// does not count against GitHub rate limit).
+//
+// A nil *Cache is valid and disable caching.
+type Cache struct {
+	dirPath string
+	ttl     time.Duration
+}
+
+type Response struct {
+	Data      json.RawMessage `json:"data"`
+	ETag      string          `json:"etag"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	URL       string          `json:"url"`
+}
+
+func NewCache(dirPath string, ttl time.Duration) *Cache {
+	return &Cache{dirPath: dirPath, ttl: ttl}
+}
+
+func (c *Cache) entryPath(callURL string) string {
+	urlSum := sha256.Sum256([]byte(callURL))
+
+	return filepath.Join(c.dirPath, hex.EncodeToString(urlSum[:])+".json")
+}
+
+// Fresh indicates that entry can be used without revalidation.
+func (c *Cache) Fresh(entry Response) bool {
+	return time.Since(entry.FetchedAt) < c.ttl
+}
+
+func (c *Cache) Get(callURL string) (Response, bool) {
+	if c == nil {
+		return Response{}, false
+	}
+
+	data, err := os.ReadFile(c.entryPath(callURL))
+	if err != nil {
+		return Response{}, false
+	}
+
+	var entry Response
+	if err = json.Unmarshal(data, &entry); err != nil || entry.URL != callURL {
+		return Response{}, false
+	}
+
+	return entry, true
+}
+
+// Put stores data with its etag (errors are ignored, the cache is only an optimization).
+func (c *Cache) Put(callURL string, etag string, data []byte) {
+	if c == nil || etag == "" || !json.Valid(data) {
+		return
+	}
+
+	entryData, err := json.Marshal(Response{Data: data, ETag: etag, FetchedAt: time.Now(), URL: callURL})
+	if err != nil {
+		return
+	}
+
+	if err = os.MkdirAll(c.dirPath, fileperm.RWE); err != nil {
+		return
+	}
+
+	entryPath := c.entryPath(callURL)
+	tmpFile, err := os.CreateTemp(c.dirPath, ".tmp-*")
+	if err != nil {
+		return
+	}
+	tmpPath := tmpFile.Name()
+
+	_, err = tmpFile.Write(entryData)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmpPath, entryPath)
+	}
+
+	if err != nil {
+		os.Remove(tmpPath)
+	}
+}
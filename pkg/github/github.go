@@ -20,6 +20,7 @@ package github
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/url"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/tofuutils/tenv/v4/pkg/apimsg"
 	"github.com/tofuutils/tenv/v4/pkg/download"
+	githubcache "github.com/tofuutils/tenv/v4/pkg/github/cache"
 	versionfinder "github.com/tofuutils/tenv/v4/versionmanager/semantic/finder"
 )
 
@@ -39,7 +41,7 @@ const (
 
 var errContinue = errors.New("continue")
 
-func AssetDownloadURL(ctx context.Context, tag string, searchedAssetNames []string, githubReleaseURL string, githubToken string, display func(string)) ([]string, error) {
+func AssetDownloadURL(ctx context.Context, tag string, searchedAssetNames []string, githubReleaseURL string, githubToken string, apiCache *githubcache.Cache, display func(string)) ([]string, error) {
 	releaseURL, err := url.JoinPath(githubReleaseURL, "tags", tag)
 	if err != nil {
 		return nil, err
@@ -48,7 +50,7 @@ func AssetDownloadURL(ctx context.Context, tag string, searchedAssetNames []stri
 	display(apimsg.MsgFetchRelease + releaseURL)
 
 	authorizationHeader := buildAuthorizationHeader(githubToken)
-	value, err := apiGetRequest(ctx, releaseURL, authorizationHeader)
+	value, err := apiGetRequest(ctx, apiCache, releaseURL, authorizationHeader)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +72,7 @@ func AssetDownloadURL(ctx context.Context, tag string, searchedAssetNames []stri
 	baseAssetsURL += pageQuery
 	for {
 		assetsURL := baseAssetsURL + strconv.Itoa(page)
-		value, err = apiGetRequest(ctx, assetsURL, authorizationHeader)
+		value, err = apiGetRequest(ctx, apiCache, assetsURL, authorizationHeader)
 		if err != nil {
 			return nil, err
 		}
@@ -89,7 +91,42 @@ func AssetDownloadURL(ctx context.Context, tag string, searchedAssetNames []stri
 	}
 }
 
-func ListReleases(ctx context.Context, githubReleaseURL string, githubToken string) ([]string, error) {
+// Attestations returns the Sigstore bundles of attestations published in a GitHub repository (like "https://api.github.com/repos/owner/name")
+// for an artifact sha256 (hex encoded), an empty slice when there is none.
+func Attestations(ctx context.Context, githubRepoURL string, sha256Hex string, githubToken string, apiCache *githubcache.Cache) ([][]byte, error) {
+	callURL, err := url.JoinPath(githubRepoURL, "attestations", "sha256:"+sha256Hex)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := apiGetRequest(ctx, apiCache, callURL, buildAuthorizationHeader(githubToken))
+	if err != nil {
+		return nil, err
+	}
+
+	object, _ := value.(map[string]any)
+	attestations, _ := object["attestations"].([]any) // absent when not found
+
+	dataBundles := make([][]byte, 0, len(attestations))
+	for _, attestation := range attestations {
+		attestationObject, _ := attestation.(map[string]any)
+		attestationBundle, ok := attestationObject["bundle"]
+		if !ok {
+			return nil, apimsg.ErrReturn
+		}
+
+		dataBundle, err := json.Marshal(attestationBundle)
+		if err != nil {
+			return nil, err
+		}
+
+		dataBundles = append(dataBundles, dataBundle)
+	}
+
+	return dataBundles, nil
+}
+
+func ListReleases(ctx context.Context, githubReleaseURL string, githubToken string, apiCache *githubcache.Cache) ([]string, error) {
 	basePageURL := githubReleaseURL + pageQuery
 	authorizationHeader := buildAuthorizationHeader(githubToken)
 
@@ -97,7 +134,7 @@ func ListReleases(ctx context.Context, githubReleaseURL string, githubToken stri
 	var releases []string
 	for {
 		pageURL := basePageURL + strconv.Itoa(page)
-		value, err := apiGetRequest(ctx, pageURL, authorizationHeader)
+		value, err := apiGetRequest(ctx, apiCache, pageURL, authorizationHeader)
 		if err != nil {
 			return nil, err
 		}
@@ -112,20 +149,72 @@ func ListReleases(ctx context.Context, githubReleaseURL string, githubToken stri
 	}
 }
 
-func apiGetRequest(ctx context.Context, callURL string, authorizationHeader string) (any, error) {
-	return download.JSON(ctx, callURL, download.NoDisplay, checkRateLimit, func(request *http.Request) {
+func apiGetRequest(ctx context.Context, apiCache *githubcache.Cache, callURL string, authorizationHeader string) (any, error) {
+	if download.IsReplay(ctx) {
+		apiCache = nil
+	}
+
+	entry, cached := apiCache.Get(callURL)
+	if cached && (apiCache.Fresh(entry) || download.IsOffline(ctx)) {
+		download.Record(ctx, callURL, entry.Data)
+
+		return unmarshal(entry.Data)
+	}
+
+	var etag string
+	notModified := false
+	checker := func(response *http.Response) error {
+		if cached && response.StatusCode == http.StatusNotModified {
+			notModified = true
+
+			return nil
+		}
+
+		if err := checkRateLimit(response); err != nil {
+			return err
+		}
+
+		if response.StatusCode == http.StatusOK {
+			etag = response.Header.Get("ETag")
+		}
+
+		return nil
+	}
+
+	data, err := download.Bytes(ctx, callURL, download.NoDisplay, checker, func(request *http.Request) {
 		request.Header.Set("Accept", "application/vnd.github+json")
 		if authorizationHeader != "" {
 			request.Header.Set("Authorization", authorizationHeader)
 		}
 		request.Header.Set("X-GitHub-Api-Version", "2022-11-28") //nolint
+		if cached {
+			request.Header.Set("If-None-Match", entry.ETag)
+		}
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	if notModified {
+		data, etag = entry.Data, entry.ETag
+		download.Record(ctx, callURL, data)
+	}
+	apiCache.Put(callURL, etag, data) // refresh fetch date when not modified
+
+	return unmarshal(data)
+}
+
+func unmarshal(data []byte) (any, error) {
+	var value any
+	err := json.Unmarshal(data, &value)
+
+	return value, err
 }
 
+// primary and secondary rate limits (403 or 429 with rate limit headers).
 func checkRateLimit(resp *http.Response) error {
-	rateLimitRemaining := resp.Header.Get("X-Ratelimit-Remaining")
-	if rateLimitRemaining == "0" {
-		return apimsg.ErrRateLimit
+	if reset, limited := download.RateLimitReset(resp); limited {
+		return apimsg.RateLimitError{Reset: reset}
 	}
 
 	return nil
@@ -19,13 +19,20 @@
 package github
 
 import (
+	"context"
 	_ "embed"
 	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"slices"
+	"strconv"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/tofuutils/tenv/v4/pkg/apimsg"
+	githubcache "github.com/tofuutils/tenv/v4/pkg/github/cache"
 	"github.com/tofuutils/tenv/v4/versionmanager/semantic"
 )
 
@@ -154,3 +161,105 @@ func TestExtractVersion(t *testing.T) {
 		t.Error("Unmatching result, get :", version)
 	}
 }
+
+func TestListReleasesCache(t *testing.T) {
+	t.Parallel()
+
+	var callCount, notModifiedCount atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		callCount.Add(1)
+		etag := `"page-` + request.URL.Query().Get("page") + `"`
+		if request.Header.Get("If-None-Match") == etag {
+			notModifiedCount.Add(1)
+			writer.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		writer.Header().Set("ETag", etag)
+		if request.URL.Query().Get("page") == "1" {
+			writer.Write(releasesData) //nolint
+
+			return
+		}
+		writer.Write([]byte("[]")) //nolint
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cacheDirPath := t.TempDir()
+	revalidateCache := githubcache.NewCache(cacheDirPath, 0)
+	for range 2 {
+		releases, err := ListReleases(ctx, server.URL, "", revalidateCache)
+		if err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+		if len(releases) != 4 {
+			t.Error("Unexpected releases :", releases)
+		}
+	}
+
+	if calls, notModified := callCount.Load(), notModifiedCount.Load(); calls != 4 || notModified != 2 {
+		t.Error("Expected revalidation of two pages, get calls and not modified count :", calls, notModified)
+	}
+
+	if _, err := ListReleases(ctx, server.URL, "", githubcache.NewCache(cacheDirPath, time.Hour)); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if calls := callCount.Load(); calls != 4 {
+		t.Error("Expected no call with fresh cache, get call count :", calls)
+	}
+}
+
+func TestAttestations(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/repos/owner/name/attestations/sha256:0123" {
+			writer.Write([]byte(`{"attestations":[{"bundle":{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json"},"repository_id":1}]}`)) //nolint
+
+			return
+		}
+
+		writer.WriteHeader(http.StatusNotFound)
+		writer.Write([]byte(`{"message":"Not Found"}`)) //nolint
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	dataBundles, err := Attestations(ctx, server.URL+"/repos/owner/name", "0123", "", nil)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if len(dataBundles) != 1 || string(dataBundles[0]) != `{"mediaType":"application/vnd.dev.sigstore.bundle.v0.3+json"}` {
+		t.Error("Unmatching results, get :", dataBundles)
+	}
+
+	if dataBundles, err = Attestations(ctx, server.URL+"/repos/owner/name", "4567", "", nil); err != nil || len(dataBundles) != 0 {
+		t.Error("Should return no attestation, get :", dataBundles, err)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Header().Set("X-Ratelimit-Remaining", "0")
+		writer.Header().Set("X-Ratelimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		writer.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	_, err := ListReleases(context.Background(), server.URL, "", nil)
+	if !errors.Is(err, apimsg.ErrRateLimit) {
+		t.Fatal("Expected rate limit error, get :", err)
+	}
+
+	var rateLimitErr apimsg.RateLimitError
+	if !errors.As(err, &rateLimitErr) || !rateLimitErr.Reset.Equal(reset) {
+		t.Error("Unexpected reset time, get :", rateLimitErr.Reset)
+	}
+}
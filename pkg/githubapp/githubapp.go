@@ -37,8 +37,8 @@ const githubAPIURL = "https://api.github.com/"
 
 // InstallationToken generates a GitHub App installation access token.
 // installationIDStr is optional; when empty the first installation returned
-// by the API is used.
-func InstallationToken(ctx context.Context, appIDStr, installationIDStr, pem, pemFile string) (string, error) {
+// by the API is used. The transport of httpClient is used for API calls.
+func InstallationToken(ctx context.Context, httpClient *http.Client, appIDStr, installationIDStr, pem, pemFile string) (string, error) {
 	appID, err := strconv.ParseInt(appIDStr, 10, 64)
 	if err != nil {
 		return "", fmt.Errorf("invalid %s: %w", envname.TenvGithubAppID, err)
@@ -57,12 +57,13 @@ func InstallationToken(ctx context.Context, appIDStr, installationIDStr, pem, pe
 		return "", fmt.Errorf("one of %s or %s must be set", envname.TenvGithubAppPEM, envname.TenvGithubAppPEMFile)
 	}
 
-	installationID, err := resolveInstallationID(ctx, appID, installationIDStr, keyData, githubAPIURL)
+	transport := clientTransport(httpClient)
+	installationID, err := resolveInstallationID(ctx, transport, appID, installationIDStr, keyData, githubAPIURL)
 	if err != nil {
 		return "", err
 	}
 
-	itr, err := ghinstallation.New(http.DefaultTransport, appID, installationID, keyData)
+	itr, err := ghinstallation.New(transport, appID, installationID, keyData)
 	if err != nil {
 		return "", fmt.Errorf("creating GitHub App transport: %w", err)
 	}
@@ -76,7 +77,7 @@ func InstallationToken(ctx context.Context, appIDStr, installationIDStr, pem, pe
 }
 
 // apiURL is parameterized so tests can point it at an httptest server.
-func resolveInstallationID(ctx context.Context, appID int64, installationIDStr string, keyData []byte, apiURL string) (int64, error) { //nolint:unparam
+func resolveInstallationID(ctx context.Context, transport http.RoundTripper, appID int64, installationIDStr string, keyData []byte, apiURL string) (int64, error) { //nolint:unparam
 	if installationIDStr != "" {
 		id, err := strconv.ParseInt(installationIDStr, 10, 64)
 		if err != nil {
@@ -86,7 +87,7 @@ func resolveInstallationID(ctx context.Context, appID int64, installationIDStr s
 		return id, nil
 	}
 
-	atr, err := ghinstallation.NewAppsTransport(http.DefaultTransport, appID, keyData)
+	atr, err := ghinstallation.NewAppsTransport(transport, appID, keyData)
 	if err != nil {
 		return 0, fmt.Errorf("creating GitHub App JWT transport: %w", err)
 	}
@@ -127,3 +128,11 @@ func resolveInstallationID(ctx context.Context, appID int64, installationIDStr s
 
 	return installations[0].ID, nil
 }
+
+func clientTransport(httpClient *http.Client) http.RoundTripper {
+	if httpClient == nil || httpClient.Transport == nil {
+		return http.DefaultTransport
+	}
+
+	return httpClient.Transport
+}
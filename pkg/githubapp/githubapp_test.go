@@ -49,7 +49,7 @@ func generateTestPEM(t *testing.T) []byte {
 func TestInstallationToken_InvalidAppID(t *testing.T) {
 	t.Parallel()
 
-	_, err := InstallationToken(context.Background(), "not-a-number", "", "", "")
+	_, err := InstallationToken(context.Background(), http.DefaultClient, "not-a-number", "", "", "")
 	if err == nil {
 		t.Fatal("expected error for invalid app ID, got nil")
 	}
@@ -58,7 +58,7 @@ func TestInstallationToken_InvalidAppID(t *testing.T) {
 func TestInstallationToken_NoPEM(t *testing.T) {
 	t.Parallel()
 
-	_, err := InstallationToken(context.Background(), "1", "", "", "")
+	_, err := InstallationToken(context.Background(), http.DefaultClient, "1", "", "", "")
 	if err == nil {
 		t.Fatal("expected error when neither PEM nor PEM file is set, got nil")
 	}
@@ -67,7 +67,7 @@ func TestInstallationToken_NoPEM(t *testing.T) {
 func TestInstallationToken_PEMFileNotFound(t *testing.T) {
 	t.Parallel()
 
-	_, err := InstallationToken(context.Background(), "1", "", "", "/nonexistent/path/key.pem")
+	_, err := InstallationToken(context.Background(), http.DefaultClient, "1", "", "", "/nonexistent/path/key.pem")
 	if err == nil {
 		t.Fatal("expected error for non-existent PEM file, got nil")
 	}
@@ -78,7 +78,7 @@ func TestInstallationToken_InvalidInstallationID(t *testing.T) {
 
 	keyPEM := generateTestPEM(t)
 
-	_, err := InstallationToken(context.Background(), "1", "not-a-number", string(keyPEM), "")
+	_, err := InstallationToken(context.Background(), http.DefaultClient, "1", "not-a-number", string(keyPEM), "")
 	if err == nil {
 		t.Fatal("expected error for invalid installation ID, got nil")
 	}
@@ -94,7 +94,7 @@ func TestResolveInstallationID_EmptyInstallations(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := resolveInstallationID(context.Background(), testAppID, "", keyPEM, srv.URL+"/")
+	_, err := resolveInstallationID(context.Background(), http.DefaultTransport, testAppID, "", keyPEM, srv.URL+"/")
 	if err == nil {
 		t.Fatal("expected error for empty installations list, got nil")
 	}
@@ -109,7 +109,7 @@ func TestResolveInstallationID_APIError(t *testing.T) {
 	}))
 	defer srv.Close()
 
-	_, err := resolveInstallationID(context.Background(), testAppID, "", keyPEM, srv.URL+"/")
+	_, err := resolveInstallationID(context.Background(), http.DefaultTransport, testAppID, "", keyPEM, srv.URL+"/")
 	if err == nil {
 		t.Fatal("expected error for API error response, got nil")
 	}
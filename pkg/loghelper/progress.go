@@ -0,0 +1,220 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package loghelper
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SizeUnits are binary unit prefixes (each one 1024 times the previous), shared with size parsing.
+	SizeUnits = "KMGT"
+	// UnknownTotal is the Progress Total without Content-Length.
+	UnknownTotal = -1
+
+	barWidth        = 30
+	logLineInterval = 5 * time.Second
+)
+
+// Progress of a download, Total is UnknownTotal when unknown (no Content-Length).
+type Progress struct {
+	Done     bool
+	Rate     float64 // bytes per second
+	Received int64
+	Total    int64
+	URL      string
+}
+
+// Optional interface for a Displayer, to receive download progress events.
+type ProgressDisplayer interface {
+	DisplayProgress(progress Progress)
+}
+
+type progressFuncWrapper struct {
+	Displayer
+
+	progressFunc func(Progress)
+}
+
+func (pfw progressFuncWrapper) DisplayProgress(progress Progress) {
+	pfw.progressFunc(progress)
+}
+
+// Shared by concurrent downloads : a single bar sums them, and displayed messages are written above it.
+type progressWrapper struct {
+	Displayer
+
+	active      map[string]Progress // by URL, downloads of current bar (kept until all are done)
+	barDrawn    bool
+	interactive bool
+	lastLog     time.Time
+	mutex       sync.Mutex
+	writer      io.Writer
+}
+
+// Display msg on its own line, then redraw the progress bar below it.
+func (pw *progressWrapper) Display(msg string) {
+	pw.mutex.Lock()
+	defer pw.mutex.Unlock()
+
+	if !pw.barDrawn {
+		pw.Displayer.Display(msg)
+
+		return
+	}
+
+	fmt.Fprint(pw.writer, "\r\033[K") //nolint
+	pw.Displayer.Display(msg)
+	pw.drawBar()
+}
+
+// Render progress as a bar rewritten in place when interactive, otherwise as periodic log lines.
+func (pw *progressWrapper) DisplayProgress(progress Progress) {
+	pw.mutex.Lock()
+	defer pw.mutex.Unlock()
+
+	if pw.interactive {
+		pw.active[progress.URL] = progress
+		pw.drawBar()
+		if sum := sumProgress(pw.active); sum.Done {
+			fmt.Fprintln(pw.writer) //nolint
+			pw.barDrawn = false
+			clear(pw.active)
+		}
+
+		return
+	}
+
+	if now := time.Now(); progress.Done || now.Sub(pw.lastLog) >= logLineInterval {
+		pw.lastLog = now
+		pw.Displayer.Display(Concat("Downloaded ", progressText(progress)))
+	}
+}
+
+// Wrap displayer to send download progress events to progressFunc.
+func WithProgressFunc(displayer Displayer, progressFunc func(Progress)) Displayer {
+	return progressFuncWrapper{Displayer: displayer, progressFunc: progressFunc}
+}
+
+// Wrap displayer to render download progress on writer (a progress bar when interactive is true).
+func WithProgressRendering(displayer Displayer, writer io.Writer, interactive bool) Displayer {
+	return &progressWrapper{Displayer: displayer, active: map[string]Progress{}, interactive: interactive, writer: writer}
+}
+
+// Return a function forwarding progress events to displayer when it implements ProgressDisplayer (otherwise they are ignored).
+func ProgressFunc(displayer Displayer) func(Progress) {
+	if progressDisplayer, ok := displayer.(ProgressDisplayer); ok {
+		return progressDisplayer.DisplayProgress
+	}
+
+	return NoProgress
+}
+
+func NoProgress(Progress) {}
+
+func FormatSize(size int64) string {
+	unitIndex := -1
+	value := float64(size)
+	for value >= 1024 && unitIndex < len(SizeUnits)-1 {
+		value /= 1024
+		unitIndex++
+	}
+
+	if unitIndex == -1 {
+		return strconv.FormatInt(size, 10) + "B"
+	}
+
+	return strconv.FormatFloat(value, 'f', 1, 64) + SizeUnits[unitIndex:unitIndex+1] + "iB"
+}
+
+// must be called with lock.
+func (pw *progressWrapper) drawBar() {
+	sum := sumProgress(pw.active)
+	prefix := ""
+	if len(pw.active) > 1 {
+		prefix = strconv.Itoa(len(pw.active)) + " downloads, "
+	}
+
+	fmt.Fprint(pw.writer, "\r", progressBar(sum), " ", prefix, progressText(sum), "\033[K") //nolint
+	pw.barDrawn = true
+}
+
+func progressBar(progress Progress) string {
+	if progress.Total <= 0 {
+		return ""
+	}
+
+	filled := int(progress.Received * barWidth / progress.Total)
+	filled = min(max(filled, 0), barWidth)
+
+	return Concat("[", strings.Repeat("=", filled), strings.Repeat(" ", barWidth-filled), "]")
+}
+
+// total is unknown when one of them is unknown, rate only counts ongoing downloads (until all are done).
+func sumProgress(progresses map[string]Progress) Progress {
+	sum := Progress{Done: true}
+	var doneRate float64
+	for _, progress := range progresses {
+		sum.Done = sum.Done && progress.Done
+		sum.Received += progress.Received
+		if progress.Done {
+			doneRate += progress.Rate
+		} else {
+			sum.Rate += progress.Rate
+		}
+
+		if sum.Total != UnknownTotal {
+			if progress.Total == UnknownTotal {
+				sum.Total = UnknownTotal
+			} else {
+				sum.Total += progress.Total
+			}
+		}
+	}
+
+	if sum.Done {
+		sum.Rate = doneRate
+	}
+
+	return sum
+}
+
+func progressText(progress Progress) string {
+	var textBuilder strings.Builder
+	textBuilder.WriteString(FormatSize(progress.Received))
+	if progress.Total != UnknownTotal {
+		textBuilder.WriteString(" / ")
+		textBuilder.WriteString(FormatSize(progress.Total))
+		if progress.Total > 0 {
+			textBuilder.WriteString(" (")
+			textBuilder.WriteString(strconv.FormatInt(progress.Received*100/progress.Total, 10))
+			textBuilder.WriteString("%)")
+		}
+	}
+	textBuilder.WriteString(", ")
+	textBuilder.WriteString(FormatSize(int64(progress.Rate)))
+	textBuilder.WriteString("/s")
+
+	return textBuilder.String()
+}
@@ -0,0 +1,134 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package loghelper_test
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+func TestFormatSize(t *testing.T) {
+	t.Parallel()
+
+	for size, expected := range map[int64]string{
+		0: "0B", 1023: "1023B", 1024: "1.0KiB", 1536: "1.5KiB", 5 << 20: "5.0MiB", 3 << 30: "3.0GiB", 2 << 40: "2.0TiB", 2048 << 40: "2048.0TiB",
+	} {
+		if formatted := loghelper.FormatSize(size); formatted != expected {
+			t.Error("Unmatching results for", size, ", expected", expected, "get", formatted)
+		}
+	}
+}
+
+func TestProgressRendering(t *testing.T) {
+	t.Parallel()
+
+	var output strings.Builder
+	progressFunc := loghelper.ProgressFunc(loghelper.WithProgressRendering(loghelper.InertDisplayer, &output, true))
+
+	progressFunc(loghelper.Progress{Rate: 2048, Received: 256, Total: 1024, URL: "a"})
+	if expected := "\r[=======                       ] 256B / 1.0KiB (25%), 2.0KiB/s\033[K"; output.String() != expected {
+		t.Errorf("Unmatching results, expected %q get %q", expected, output.String())
+	}
+
+	output.Reset()
+	progressFunc(loghelper.Progress{Done: true, Rate: 1024, Received: 1024, Total: 1024, URL: "a"})
+	if expected := "\r[==============================] 1.0KiB / 1.0KiB (100%), 1.0KiB/s\033[K\n"; output.String() != expected {
+		t.Errorf("Unmatching results, expected %q get %q", expected, output.String())
+	}
+
+	// without Content-Length, there is no bar nor percentage
+	output.Reset()
+	progressFunc(loghelper.Progress{Received: 2048, Total: loghelper.UnknownTotal, URL: "b"})
+	if expected := "\r 2.0KiB, 0B/s\033[K"; output.String() != expected {
+		t.Errorf("Unmatching results, expected %q get %q", expected, output.String())
+	}
+}
+
+func TestProgressRenderingLog(t *testing.T) {
+	t.Parallel()
+
+	var messages []string
+	displayer := loghelper.MakeBasicDisplayer(hclog.NewNullLogger(), func(msg string) {
+		messages = append(messages, msg)
+	})
+	progressFunc := loghelper.ProgressFunc(loghelper.WithProgressRendering(displayer, io.Discard, false))
+
+	progressFunc(loghelper.Progress{Received: 256, Total: 1024, URL: "a"})
+	progressFunc(loghelper.Progress{Received: 512, Total: 1024, URL: "a"}) // throttled
+	progressFunc(loghelper.Progress{Done: true, Received: 1024, Total: 1024, URL: "a"})
+
+	expected := []string{"Downloaded 256B / 1.0KiB (25%), 0B/s", "Downloaded 1.0KiB / 1.0KiB (100%), 0B/s"}
+	if !slices.Equal(messages, expected) {
+		t.Error("Unmatching results, get :", messages)
+	}
+}
+
+func TestProgressRenderingConcurrent(t *testing.T) {
+	t.Parallel()
+
+	var output strings.Builder
+	displayer := loghelper.MakeBasicDisplayer(hclog.NewNullLogger(), func(msg string) {
+		output.WriteString(msg + "\n")
+	})
+	progressFunc := loghelper.ProgressFunc(loghelper.WithProgressRendering(displayer, &output, true))
+
+	progressFunc(loghelper.Progress{Received: 50, Total: 100, URL: "a"})
+	progressFunc(loghelper.Progress{Received: 10, Total: 100, URL: "b"})
+
+	lines := strings.Split(output.String(), "\r")
+	if last := lines[len(lines)-1]; !strings.Contains(last, "2 downloads, 60B / 200B (30%)") {
+		t.Error("Unmatching results, expected one bar summing downloads, get :", last)
+	}
+
+	progressFunc(loghelper.Progress{Done: true, Received: 100, Total: 100, URL: "a"})
+	if strings.Contains(output.String(), "\n") {
+		t.Error("Unexpected new line before all downloads are done, get :", output.String())
+	}
+
+	progressFunc(loghelper.Progress{Done: true, Received: 100, Total: 100, URL: "b"})
+	if !strings.HasSuffix(output.String(), "2 downloads, 200B / 200B (100%), 0B/s\033[K\n") {
+		t.Error("Unmatching results, expected final line, get :", output.String())
+	}
+}
+
+func TestProgressRenderingDisplay(t *testing.T) {
+	t.Parallel()
+
+	var output strings.Builder
+	displayer := loghelper.MakeBasicDisplayer(hclog.NewNullLogger(), func(msg string) {
+		output.WriteString(msg + "\n")
+	})
+	progressDisplayer := loghelper.WithProgressRendering(displayer, &output, true)
+	progressFunc := loghelper.ProgressFunc(progressDisplayer)
+
+	progressFunc(loghelper.Progress{Received: 512, Total: 1024, URL: "a"})
+	output.Reset()
+	progressDisplayer.Display("message")
+
+	// bar line is cleared before the message, then redrawn below it
+	if !strings.HasPrefix(output.String(), "\r\033[Kmessage\n\r[===============               ] 512B / 1.0KiB (50%)") {
+		t.Error("Unmatching results, get :", output.String())
+	}
+}
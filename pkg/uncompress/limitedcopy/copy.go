@@ -22,13 +22,21 @@ import (
 	"errors"
 	"io"
 	"os"
+	"path/filepath"
+
+	"github.com/tofuutils/tenv/v4/pkg/fileperm"
 )
 
 const copyAllowedSize = 200 << 20 // 200MB, should be enough for our use cases.
 
 var errFileTooBig = errors.New("file too big, max allowed size is 200MB")
 
+// parent directory is created when needed (archive can omit directory entries or they can be filtered).
 func Copy(destPath string, reader io.Reader, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(destPath), fileperm.RWE); err != nil {
+		return err
+	}
+
 	destFile, err := os.OpenFile(destPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
 	if err != nil {
 		return err
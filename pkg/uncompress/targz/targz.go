@@ -20,7 +20,7 @@ package targz
 
 import (
 	"archive/tar"
-	"bytes"
+	"bufio"
 	"compress/gzip"
 	"fmt"
 	"os"
@@ -30,8 +30,14 @@ import (
 	"github.com/tofuutils/tenv/v4/pkg/uncompress/sanitize"
 )
 
-func UntarToDir(dataTarGz []byte, dirPath string, filter func(string) bool) error {
-	uncompressedStream, err := gzip.NewReader(bytes.NewReader(dataTarGz))
+func UntarToDir(archivePath string, dirPath string, filter func(string) bool) error {
+	archiveFile, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archiveFile.Close()
+
+	uncompressedStream, err := gzip.NewReader(bufio.NewReader(archiveFile))
 	if err != nil {
 		return err
 	}
@@ -30,18 +30,18 @@ import (
 
 var errArchive = errors.New("unknown archive kind")
 
-// ensure the directory exists with a MkdirAll call.
-func ToDir(data []byte, filePath string, dirPath string, filter func(string) bool) error {
+// ensure the directory exists with a MkdirAll call (fileName is only used to detect archive kind).
+func ToDir(archivePath string, fileName string, dirPath string, filter func(string) bool) error {
 	err := os.MkdirAll(dirPath, fileperm.RWE)
 	if err != nil {
 		return err
 	}
 
 	switch {
-	case strings.HasSuffix(filePath, ".tar.gz"):
-		return targz.UntarToDir(data, dirPath, filter)
-	case strings.HasSuffix(filePath, ".zip"):
-		return zip.UnzipToDir(data, dirPath, filter)
+	case strings.HasSuffix(fileName, ".tar.gz"):
+		return targz.UntarToDir(archivePath, dirPath, filter)
+	case strings.HasSuffix(fileName, ".zip"):
+		return zip.UnzipToDir(archivePath, dirPath, filter)
 	}
 
 	return errArchive
@@ -20,7 +20,6 @@ package zip
 
 import (
 	"archive/zip"
-	"bytes"
 	"os"
 
 	"github.com/tofuutils/tenv/v4/pkg/fileperm"
@@ -28,12 +27,12 @@ import (
 	"github.com/tofuutils/tenv/v4/pkg/uncompress/sanitize"
 )
 
-func UnzipToDir(dataZip []byte, dirPath string, filter func(string) bool) error {
-	dataReader := bytes.NewReader(dataZip)
-	zipReader, err := zip.NewReader(dataReader, int64(len(dataZip)))
+func UnzipToDir(archivePath string, dirPath string, filter func(string) bool) error {
+	zipReader, err := zip.OpenReader(archivePath)
 	if err != nil {
 		return err
 	}
+	defer zipReader.Close()
 
 	for _, file := range zipReader.File {
 		if err = copyZipFileToDir(file, dirPath, filter); err != nil {
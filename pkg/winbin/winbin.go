@@ -31,7 +31,11 @@ const (
 )
 
 func GetArchiveFormat() string {
-	if runtime.GOOS == osName {
+	return GetArchiveFormatForOS(runtime.GOOS)
+}
+
+func GetArchiveFormatForOS(goos string) string {
+	if goos == osName {
 		return zipSuffix
 	}
 
@@ -47,7 +51,11 @@ func GetBinaryName(execName string) string {
 }
 
 func WriteSuffixTo(writer io.StringWriter) (int, error) {
-	if runtime.GOOS != osName {
+	return WriteSuffixForOSTo(runtime.GOOS, writer)
+}
+
+func WriteSuffixForOSTo(goos string, writer io.StringWriter) (int, error) {
+	if goos != osName {
 		return 0, nil
 	}
 
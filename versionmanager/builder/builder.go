@@ -25,10 +25,16 @@ import (
 	"github.com/tofuutils/tenv/v4/config/cmdconst"
 	"github.com/tofuutils/tenv/v4/config/envname"
 	"github.com/tofuutils/tenv/v4/versionmanager"
+	"github.com/tofuutils/tenv/v4/versionmanager/projectlock"
 	atmosretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/atmos"
+	declaredretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/declared"
+	infracostretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/infracost"
+	pluginretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/plugin"
 	terraformretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/terraform"
+	terraformdocsretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/terraformdocs"
 	terragruntretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/terragrunt"
 	terramateretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/terramate"
+	tflintretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/tflint"
 	tofuretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/tofu"
 	asdfparser "github.com/tofuutils/tenv/v4/versionmanager/semantic/parser/asdf"
 	flatparser "github.com/tofuutils/tenv/v4/versionmanager/semantic/parser/flat"
@@ -39,18 +45,52 @@ import (
 )
 
 var Builders = map[string]Func{ //nolint
-	cmdconst.TofuName:       BuildTofuManager,
-	cmdconst.TerraformName:  BuildTfManager,
-	cmdconst.TerragruntName: BuildTgManager,
-	cmdconst.TerramateName:  BuildTmManager,
-	cmdconst.AtmosName:      BuildAtmosManager,
+	cmdconst.TofuName:          BuildTofuManager,
+	cmdconst.TerraformName:     BuildTfManager,
+	cmdconst.TerragruntName:    BuildTgManager,
+	cmdconst.TerramateName:     BuildTmManager,
+	cmdconst.AtmosName:         BuildAtmosManager,
+	cmdconst.TflintName:        BuildTflintManager,
+	cmdconst.TerraformDocsName: BuildTfDocsManager,
+	cmdconst.InfracostName:     BuildInfracostManager,
 }
 
 type Func = func(*config.Config, *hclparse.Parser) versionmanager.VersionManager
 
+// AddDeclaredTools registers builders of tools declared in configuration (see config.InitToolDefs).
+func AddDeclaredTools(conf *config.Config) {
+	for _, def := range conf.Tools {
+		Builders[def.Name] = func(conf *config.Config, _ *hclparse.Parser) versionmanager.VersionManager {
+			return BuildDeclaredManager(conf, def)
+		}
+	}
+}
+
+// AddPluginTools registers builders of tools handled by plugins (see config.InitPlugins).
+func AddPluginTools(conf *config.Config) {
+	for _, plugin := range conf.Plugins {
+		Builders[plugin.Name] = func(conf *config.Config, _ *hclparse.Parser) versionmanager.VersionManager {
+			return BuildPluginManager(conf, plugin)
+		}
+	}
+}
+
+func BuildDeclaredManager(conf *config.Config, def *config.ToolDefinition) versionmanager.VersionManager {
+	declaredRetriever := declaredretriever.Make(conf, def)
+
+	return versionmanager.Make(conf, def.EnvNames().Prefix, def.Name, nil, declaredRetriever, buildVersionFiles(def.Name, def.VersionFiles...))
+}
+
+func BuildPluginManager(conf *config.Config, plugin *config.Plugin) versionmanager.VersionManager {
+	pluginRetriever := pluginretriever.Make(conf, plugin)
+
+	return versionmanager.Make(conf, plugin.EnvNames().Prefix, plugin.Name, nil, pluginRetriever, buildVersionFiles(plugin.Name, "."+plugin.Name+"-version"))
+}
+
 func BuildAtmosManager(conf *config.Config, _ *hclparse.Parser) versionmanager.VersionManager {
 	atmosRetriever := atmosretriever.Make(conf)
 	versionFiles := []types.VersionFile{
+		projectlock.VersionFile(cmdconst.AtmosName),
 		{Name: ".atmos-version", Parser: flatparser.RetrieveVersion},
 		{Name: asdfparser.ToolFileName, Parser: asdfparser.RetrieveAtmosVersion},
 	}
@@ -58,10 +98,33 @@ func BuildAtmosManager(conf *config.Config, _ *hclparse.Parser) versionmanager.V
 	return versionmanager.Make(conf, envname.AtmosPrefix, "Atmos", nil, atmosRetriever, versionFiles)
 }
 
+func BuildInfracostManager(conf *config.Config, _ *hclparse.Parser) versionmanager.VersionManager {
+	infracostRetriever := infracostretriever.Make(conf)
+	versionFiles := []types.VersionFile{
+		projectlock.VersionFile(cmdconst.InfracostName),
+		{Name: ".infracost-version", Parser: flatparser.RetrieveVersion},
+		{Name: asdfparser.ToolFileName, Parser: asdfparser.RetrieveInfracostVersion},
+	}
+
+	return versionmanager.Make(conf, envname.InfracostPrefix, "Infracost", nil, infracostRetriever, versionFiles)
+}
+
+func BuildTfDocsManager(conf *config.Config, _ *hclparse.Parser) versionmanager.VersionManager {
+	tfDocsRetriever := terraformdocsretriever.Make(conf)
+	versionFiles := []types.VersionFile{
+		projectlock.VersionFile(cmdconst.TerraformDocsName),
+		{Name: ".terraform-docs-version", Parser: flatparser.RetrieveVersion},
+		{Name: asdfparser.ToolFileName, Parser: asdfparser.RetrieveTfDocsVersion},
+	}
+
+	return versionmanager.Make(conf, envname.TfDocsPrefix, "TerraformDocs", nil, tfDocsRetriever, versionFiles)
+}
+
 func BuildTfManager(conf *config.Config, hclParser *hclparse.Parser) versionmanager.VersionManager {
 	tfRetriever := terraformretriever.Make(conf)
 	gruntParser := terragruntparser.Make(hclParser)
 	versionFiles := []types.VersionFile{
+		projectlock.VersionFile(cmdconst.TerraformName),
 		{Name: ".terraform-version", Parser: flatparser.RetrieveVersion},
 		{Name: ".tfswitchrc", Parser: flatparser.RetrieveVersion},
 		{Name: asdfparser.ToolFileName, Parser: asdfparser.RetrieveTfVersion},
@@ -79,10 +142,22 @@ func BuildTfManager(conf *config.Config, hclParser *hclparse.Parser) versionmana
 	return versionmanager.Make(conf, envname.TfenvTerraformPrefix, "Terraform", iacExts, tfRetriever, versionFiles)
 }
 
+func BuildTflintManager(conf *config.Config, _ *hclparse.Parser) versionmanager.VersionManager {
+	tflintRetriever := tflintretriever.Make(conf)
+	versionFiles := []types.VersionFile{
+		projectlock.VersionFile(cmdconst.TflintName),
+		{Name: ".tflint-version", Parser: flatparser.RetrieveVersion},
+		{Name: asdfparser.ToolFileName, Parser: asdfparser.RetrieveTflintVersion},
+	}
+
+	return versionmanager.Make(conf, envname.TflintPrefix, "TFLint", nil, tflintRetriever, versionFiles)
+}
+
 func BuildTgManager(conf *config.Config, hclParser *hclparse.Parser) versionmanager.VersionManager {
 	tgRetriever := terragruntretriever.Make(conf)
 	gruntParser := terragruntparser.Make(hclParser)
 	versionFiles := []types.VersionFile{
+		projectlock.VersionFile(cmdconst.TerragruntName),
 		{Name: ".terragrunt-version", Parser: flatparser.RetrieveVersion},
 		{Name: ".tgswitchrc", Parser: flatparser.RetrieveVersion},
 		{Name: ".tgswitch.toml", Parser: tomlparser.RetrieveVersion},
@@ -99,6 +174,7 @@ func BuildTgManager(conf *config.Config, hclParser *hclparse.Parser) versionmana
 func BuildTmManager(conf *config.Config, _ *hclparse.Parser) versionmanager.VersionManager {
 	tmRetriever := terramateretriever.Make(conf)
 	versionFiles := []types.VersionFile{
+		projectlock.VersionFile(cmdconst.TerramateName),
 		{Name: ".terramate-version", Parser: flatparser.RetrieveVersion},
 		{Name: asdfparser.ToolFileName, Parser: asdfparser.RetrieveTmVersion},
 	}
@@ -110,6 +186,7 @@ func BuildTofuManager(conf *config.Config, hclParser *hclparse.Parser) versionma
 	tofuRetriever := tofuretriever.Make(conf)
 	gruntParser := terragruntparser.Make(hclParser)
 	versionFiles := []types.VersionFile{
+		projectlock.VersionFile(cmdconst.TofuName),
 		{Name: ".opentofu-version", Parser: flatparser.RetrieveVersion},
 		{Name: asdfparser.ToolFileName, Parser: asdfparser.RetrieveTofuVersion},
 		{Name: terragruntparser.HCLNameLegacy, Parser: gruntParser.RetrieveTerraformVersionConstraintFromHCL},
@@ -127,3 +204,14 @@ func BuildTofuManager(conf *config.Config, hclParser *hclparse.Parser) versionma
 
 	return versionmanager.Make(conf, envname.TofuenvTofuPrefix, "OpenTofu", iacExts, tofuRetriever, versionFiles)
 }
+
+// project lock file, flat version files and asdf tool file.
+func buildVersionFiles(toolName string, fileNames ...string) []types.VersionFile {
+	versionFiles := make([]types.VersionFile, 0, len(fileNames)+2)
+	versionFiles = append(versionFiles, projectlock.VersionFile(toolName))
+	for _, fileName := range fileNames {
+		versionFiles = append(versionFiles, types.VersionFile{Name: fileName, Parser: flatparser.RetrieveVersion})
+	}
+
+	return append(versionFiles, types.VersionFile{Name: asdfparser.ToolFileName, Parser: asdfparser.ToolVersionRetriever(toolName)})
+}
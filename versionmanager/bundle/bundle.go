@@ -0,0 +1,525 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/tofuutils/tenv/v4/config"
+	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/fileperm"
+	"github.com/tofuutils/tenv/v4/pkg/lockfile"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/pkg/uncompress/targz"
+	"github.com/tofuutils/tenv/v4/versionmanager"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
+	versionfinder "github.com/tofuutils/tenv/v4/versionmanager/semantic/finder"
+)
+
+const (
+	ManifestName = "tenv-bundle.json"
+
+	lastUseFileName = "last-use.txt" // machine specific, not bundled
+	tmpDirPattern   = ".bundle-*"
+	upstreamDirName = ".upstream" // not a valid tool name
+)
+
+var (
+	ErrChecksum       = errors.New("bundle checksum mismatch")
+	ErrDuplicate      = errors.New("duplicate bundled version")
+	ErrFileChecksum   = errors.New("bundled file checksum mismatch")
+	ErrInvalidVersion = errors.New("invalid bundled version")
+	ErrNoChecksum     = errors.New("expected bundle checksum is required (obtained through a trusted channel)")
+	ErrNoUpstream     = errors.New("no bundled upstream checksums (strict validation)")
+	ErrNotInstalled   = errors.New("version not installed")
+	ErrUnexpected     = errors.New("unexpected bundled file")
+	ErrUnknownTool    = errors.New("unknown tool in bundle")
+	ErrUpstreamSum    = errors.New("recorded archive checksum does not match bundled upstream one")
+)
+
+// Entry describes a bundled tool version, Files maps relative paths to their sha256 (hex encoded).
+//
+// Upstream maps URLs downloaded to get the release archive checksum (checksums file, signatures and release metadata)
+// to the sha256 of their content, bundled in upstreamDirName and checked again on import.
+type Entry struct {
+	Arch     string            `json:"arch,omitempty"`
+	Files    map[string]string `json:"files"`
+	OS       string            `json:"os,omitempty"`
+	Tool     string            `json:"tool"`
+	Upstream map[string]string `json:"upstream,omitempty"`
+	Version  string            `json:"version"`
+}
+
+type Manifest struct {
+	CreatedAt time.Time `json:"created_at"`
+	Entries   []Entry   `json:"entries"`
+}
+
+// Item selects an installed version to bundle, Tool is the name used to find its manager on import.
+type Item struct {
+	Manager versionmanager.VersionManager
+	Tool    string
+	Version string
+}
+
+// Create writes a tar.gz bundle of items in archivePath and returns its sha256.
+// Upstream files giving the release archive checksum of each item are fetched (or read from caches) and bundled too,
+// duplicate items are bundled once.
+func Create(ctx context.Context, conf *config.Config, archivePath string, items []Item) ([]byte, error) {
+	archiveFile, err := os.CreateTemp(filepath.Dir(archivePath), filepath.Base(archivePath)+".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+	tmpPath := archiveFile.Name()
+
+	hasher := sha256.New()
+	err = writeBundle(ctx, conf, io.MultiWriter(archiveFile, hasher), items)
+	if closeErr := archiveFile.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(tmpPath, archivePath)
+	}
+
+	if err != nil {
+		os.Remove(tmpPath)
+
+		return nil, err
+	}
+
+	return hasher.Sum(nil), nil
+}
+
+// Import verifies a bundle (whole file against expectedSum, then each file against the manifest, then the recorded
+// archive checksum of each version against the bundled upstream files, with their signatures checked by the tool retriever)
+// and moves its versions into conf.RootPath. Already installed versions are skipped unless force is true.
+//
+// The manifest is part of the bundle, so expectedSum is mandatory : it must come from a channel distinct from the bundle one.
+func Import(ctx context.Context, conf *config.Config, archivePath string, expectedSum []byte, managers map[string]versionmanager.VersionManager, force bool) ([]Entry, error) {
+	if len(expectedSum) == 0 {
+		return nil, ErrNoChecksum
+	}
+
+	sum, err := sha256check.File(archivePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(sum, expectedSum) {
+		return nil, ErrChecksum
+	}
+
+	if err = os.MkdirAll(conf.RootPath, fileperm.RWE); err != nil {
+		return nil, err
+	}
+
+	// staging in root path allows to move versions with a rename
+	stagingPath, err := os.MkdirTemp(conf.RootPath, tmpDirPattern)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(stagingPath)
+
+	if err = targz.UntarToDir(archivePath, stagingPath, func(string) bool { return true }); err != nil {
+		return nil, err
+	}
+
+	bundleManifest, err := readManifest(filepath.Join(stagingPath, ManifestName))
+	if err != nil {
+		return nil, err
+	}
+
+	bundled := make(map[string]struct{}, len(bundleManifest.Entries))
+	for _, entry := range bundleManifest.Entries {
+		key := entry.Tool + "@" + entry.Version
+		if _, ok := bundled[key]; ok {
+			return nil, fmt.Errorf("%w : %s", ErrDuplicate, key)
+		}
+		bundled[key] = struct{}{}
+	}
+
+	for _, entry := range bundleManifest.Entries {
+		if err = verifyEntry(stagingPath, entry, managers); err != nil {
+			return nil, err
+		}
+
+		if err = verifyUpstream(ctx, conf, stagingPath, entry, managers[entry.Tool]); err != nil {
+			return nil, err
+		}
+	}
+
+	imported := make([]Entry, 0, len(bundleManifest.Entries))
+	for _, entry := range bundleManifest.Entries {
+		done, err := moveEntry(conf, stagingPath, entry, managers[entry.Tool], force)
+		if err != nil {
+			return imported, err
+		}
+
+		if done {
+			imported = append(imported, entry)
+		}
+	}
+
+	return imported, nil
+}
+
+func moveEntry(conf *config.Config, stagingPath string, entry Entry, manager versionmanager.VersionManager, force bool) (bool, error) {
+	installPath, err := manager.InstallPath()
+	if err != nil {
+		return false, err
+	}
+
+	deleteLock := lockfile.WriteWithCustomLockPath(conf.LockPath, manager.FolderName, conf.Displayer)
+	disableExit := lockfile.CleanAndExitOnInterrupt(deleteLock)
+	defer disableExit()
+	defer deleteLock()
+
+	targetPath := filepath.Join(installPath, entry.Version)
+	if _, err = os.Stat(targetPath); err == nil {
+		switch {
+		case versionmanager.IncompleteInstall(targetPath):
+			conf.Displayer.Log(hclog.Warn, "Replace incomplete installation", "path", targetPath)
+		case !force:
+			conf.Displayer.Display(loghelper.Concat(manager.FolderName, " ", entry.Version, " already installed, skipped"))
+
+			return false, nil
+		}
+
+		if err = os.RemoveAll(targetPath); err != nil {
+			return false, err
+		}
+	}
+
+	if err = os.Rename(filepath.Join(stagingPath, entry.Tool, entry.Version), targetPath); err != nil {
+		return false, err
+	}
+
+	conf.Displayer.Display(loghelper.Concat("Imported ", manager.FolderName, " ", entry.Version))
+
+	return true, nil
+}
+
+func readManifest(manifestPath string) (Manifest, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	var bundleManifest Manifest
+	err = json.Unmarshal(data, &bundleManifest)
+
+	return bundleManifest, err
+}
+
+// check bundled upstream files and that the tool retriever, served with them, gives the archive checksum recorded at installation.
+func verifyUpstream(ctx context.Context, conf *config.Config, stagingPath string, entry Entry, manager versionmanager.VersionManager) error {
+	if len(entry.Upstream) == 0 {
+		if conf.StrictValidation {
+			return fmt.Errorf("%w : %s %s", ErrNoUpstream, entry.Tool, entry.Version)
+		}
+
+		conf.Displayer.Display(loghelper.Concat("Warning, no upstream checksums bundled for ", entry.Tool, " ", entry.Version, ", only bundle checksum is checked"))
+
+		return nil
+	}
+
+	responses := make(map[string][]byte, len(entry.Upstream))
+	for url, expected := range entry.Upstream {
+		if decoded, err := hex.DecodeString(expected); err != nil || len(decoded) != sha256.Size {
+			return fmt.Errorf("%w : upstream %s", ErrFileChecksum, url)
+		}
+
+		data, err := os.ReadFile(filepath.Join(stagingPath, upstreamDirName, expected))
+		if err != nil {
+			return err
+		}
+
+		if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != expected {
+			return fmt.Errorf("%w : upstream %s", ErrFileChecksum, url)
+		}
+		responses[url] = data
+	}
+
+	installManifest, err := manifest.Read(filepath.Join(stagingPath, entry.Tool, entry.Version))
+	if err != nil {
+		return err
+	}
+
+	upstreamSum, err := manager.Checksum(download.WithReplay(ctx, responses), entry.Version, entry.OS, entry.Arch)
+	if err != nil {
+		return err
+	}
+
+	if upstreamSum != installManifest.ArchiveSHA256 {
+		return fmt.Errorf("%w : %s %s", ErrUpstreamSum, entry.Tool, entry.Version)
+	}
+
+	return nil
+}
+
+// check that staged files match exactly the manifest entry.
+func verifyEntry(stagingPath string, entry Entry, managers map[string]versionmanager.VersionManager) error {
+	if _, ok := managers[entry.Tool]; !ok {
+		return fmt.Errorf("%w : %s", ErrUnknownTool, entry.Tool)
+	}
+
+	if !versionfinder.IsValid(entry.Version) || versionfinder.Clean(entry.Version) != entry.Version {
+		return fmt.Errorf("%w : %s", ErrInvalidVersion, entry.Version)
+	}
+
+	versionPath := filepath.Join(stagingPath, entry.Tool, entry.Version)
+	found := 0
+	err := filepath.WalkDir(versionPath, func(filePath string, dirEntry fs.DirEntry, err error) error {
+		if err != nil || dirEntry.IsDir() {
+			return err
+		}
+
+		relPath, err := filepath.Rel(versionPath, filePath)
+		if err != nil {
+			return err
+		}
+
+		relPath = filepath.ToSlash(relPath)
+		expected, ok := entry.Files[relPath]
+		if !ok {
+			return fmt.Errorf("%w : %s/%s/%s", ErrUnexpected, entry.Tool, entry.Version, relPath)
+		}
+
+		sum, err := sha256check.File(filePath)
+		if err != nil {
+			return err
+		}
+
+		if hex.EncodeToString(sum) != expected {
+			return fmt.Errorf("%w : %s/%s/%s", ErrFileChecksum, entry.Tool, entry.Version, relPath)
+		}
+		found++
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if found != len(entry.Files) {
+		return fmt.Errorf("%w : missing files for %s %s", ErrFileChecksum, entry.Tool, entry.Version)
+	}
+
+	return nil
+}
+
+func writeBundle(ctx context.Context, conf *config.Config, writer io.Writer, items []Item) error {
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	bundleManifest := Manifest{CreatedAt: time.Now().UTC(), Entries: make([]Entry, 0, len(items))}
+	bundled, upstreamWritten := make(map[string]struct{}, len(items)), map[string]struct{}{}
+	for _, item := range items {
+		key := item.Tool + "@" + item.Version
+		if _, ok := bundled[key]; ok {
+			continue
+		}
+		bundled[key] = struct{}{}
+
+		entry, err := writeItem(tarWriter, item)
+		if err != nil {
+			return err
+		}
+
+		if entry.Upstream, err = writeUpstream(ctx, conf, tarWriter, item, upstreamWritten, bundleManifest.CreatedAt); err != nil {
+			return err
+		}
+		entry.OS, entry.Arch = runtime.GOOS, conf.Arch
+		bundleManifest.Entries = append(bundleManifest.Entries, entry)
+
+		conf.Displayer.Display(loghelper.Concat("Bundled ", item.Manager.FolderName, " ", item.Version))
+	}
+
+	data, err := json.MarshalIndent(bundleManifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err = writeData(tarWriter, ManifestName, data, bundleManifest.CreatedAt); err != nil {
+		return err
+	}
+
+	if err = tarWriter.Close(); err != nil {
+		return err
+	}
+
+	return gzipWriter.Close()
+}
+
+func writeData(tarWriter *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{Name: name, Mode: int64(fileperm.RW), ModTime: modTime, Size: int64(len(data)), Typeflag: tar.TypeReg}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	_, err := tarWriter.Write(data)
+
+	return err
+}
+
+func writeItem(tarWriter *tar.Writer, item Item) (Entry, error) {
+	installPath, err := item.Manager.InstallPath()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	versionPath := filepath.Join(installPath, item.Version)
+	if _, err = os.Stat(versionPath); err != nil {
+		return Entry{}, fmt.Errorf("%w : %s %s", ErrNotInstalled, item.Manager.FolderName, item.Version)
+	}
+
+	entry := Entry{Files: map[string]string{}, Tool: item.Tool, Version: item.Version}
+	err = filepath.WalkDir(versionPath, func(filePath string, dirEntry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(versionPath, filePath)
+		if err != nil || relPath == lastUseFileName {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		info, err := dirEntry.Info()
+		if err != nil {
+			return err
+		}
+
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(item.Tool, item.Version, relPath)
+
+		if dirEntry.IsDir() {
+			header.Name += "/"
+
+			return tarWriter.WriteHeader(header)
+		}
+
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("%w : %s", ErrUnexpected, filePath)
+		}
+
+		if err = tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+
+		sum, err := copyFile(tarWriter, filePath)
+		if err != nil {
+			return err
+		}
+		entry.Files[relPath] = hex.EncodeToString(sum)
+
+		return nil
+	})
+
+	return entry, err
+}
+
+// record files downloaded by item retriever to get its release archive checksum and write them in upstreamDirName
+// (once for all items), return their sha256 by URL.
+func writeUpstream(ctx context.Context, conf *config.Config, tarWriter *tar.Writer, item Item, written map[string]struct{}, modTime time.Time) (map[string]string, error) {
+	installManifest, err := item.Manager.Info(item.Version)
+	if err != nil {
+		if errors.Is(err, manifest.ErrNoManifest) {
+			conf.Displayer.Display(loghelper.Concat("No manifest for ", item.Manager.FolderName, " ", item.Version, ", upstream checksums not bundled"))
+
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	recorder := download.NewRecorder()
+	upstreamSum, err := item.Manager.Checksum(download.WithRecorder(ctx, recorder), item.Version, runtime.GOOS, conf.Arch)
+	if err != nil {
+		if errors.Is(err, versionmanager.ErrNoChecksum) {
+			conf.Displayer.Display(loghelper.Concat("No upstream checksum for ", item.Manager.FolderName, " ", item.Version, ", upstream checksums not bundled"))
+
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if upstreamSum != installManifest.ArchiveSHA256 {
+		return nil, fmt.Errorf("%w : %s %s", ErrUpstreamSum, item.Manager.FolderName, item.Version)
+	}
+
+	responses := recorder.Responses()
+	upstream := make(map[string]string, len(responses))
+	for _, url := range slices.Sorted(maps.Keys(responses)) {
+		data := responses[url]
+		sum := sha256.Sum256(data)
+		hexSum := hex.EncodeToString(sum[:])
+		upstream[url] = hexSum
+		if _, ok := written[hexSum]; ok {
+			continue
+		}
+		written[hexSum] = struct{}{}
+
+		if err = writeData(tarWriter, path.Join(upstreamDirName, hexSum), data, modTime); err != nil {
+			return nil, err
+		}
+	}
+
+	return upstream, nil
+}
+
+func copyFile(writer io.Writer, filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	hasher := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(writer, hasher), file); err != nil {
+		return nil, err
+	}
+
+	return hasher.Sum(nil), nil
+}
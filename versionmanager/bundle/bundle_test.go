@@ -0,0 +1,291 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tofuutils/tenv/v4/config"
+	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/versionmanager"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
+)
+
+const archiveSum = "53ae027a22b07078809309bd61dbecfa6ba8614633fc1ade165f05d4dbcb9dff"
+
+var errStatus = errors.New("unexpected status")
+
+// sumsRetriever reads archive checksum in a checksums file downloaded from sumsURL.
+type sumsRetriever struct {
+	sumsURL string
+}
+
+func (r sumsRetriever) Checksum(ctx context.Context, _ string, _ string, _ string) (string, error) {
+	data, err := download.Bytes(ctx, r.sumsURL, download.NoDisplay, func(response *http.Response) error {
+		if response.StatusCode != http.StatusOK {
+			return errStatus
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum, _, _ := strings.Cut(string(data), " ")
+
+	return sum, nil
+}
+
+func (sumsRetriever) Install(context.Context, string, string) error {
+	return nil
+}
+
+func (sumsRetriever) ListVersions(context.Context) ([]string, error) {
+	return nil, nil
+}
+
+func TestBundle(t *testing.T) {
+	t.Parallel()
+
+	srcConf := testConf(t)
+	srcManager := versionmanager.Make(srcConf, "TFENV_", "Terraform", nil, nil, nil)
+	versionPath := filepath.Join(srcConf.RootPath, "Terraform", "1.5.7")
+	if err := os.MkdirAll(filepath.Join(versionPath, "sub"), 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	for name, content := range map[string]string{"terraform": "binary", "sub/LICENSE": "license", lastUseFileName: "2026-01-01"} {
+		if err := os.WriteFile(filepath.Join(versionPath, name), []byte(content), 0o755); err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	sum, err := Create(t.Context(), srcConf, archivePath, []Item{{Manager: srcManager, Tool: "terraform", Version: "1.5.7"}})
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	destConf := testConf(t)
+	managers := map[string]versionmanager.VersionManager{"terraform": versionmanager.Make(destConf, "TFENV_", "Terraform", nil, nil, nil)}
+	if _, err = Import(t.Context(), destConf, archivePath, nil, managers, false); !errors.Is(err, ErrNoChecksum) {
+		t.Error("Expected missing checksum error, get :", err)
+	}
+
+	if _, err = Import(t.Context(), destConf, archivePath, []byte("wrong sum"), managers, false); !errors.Is(err, ErrChecksum) {
+		t.Error("Expected checksum error, get :", err)
+	}
+
+	imported, err := Import(t.Context(), destConf, archivePath, sum, managers, false)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if len(imported) != 1 || len(imported[0].Files) != 2 {
+		t.Error("Unexpected imported entries :", imported)
+	}
+
+	data, err := os.ReadFile(filepath.Join(destConf.RootPath, "Terraform", "1.5.7", "sub", "LICENSE"))
+	if err != nil || string(data) != "license" {
+		t.Error("Unexpected imported content :", string(data), err)
+	}
+
+	if imported, err = Import(t.Context(), destConf, archivePath, sum, managers, false); err != nil || len(imported) != 0 {
+		t.Error("Expected already installed version to be skipped, get :", imported, err)
+	}
+
+	// simulate an interrupted installation (only last use date written), it must be replaced even without force
+	installedPath := filepath.Join(destConf.RootPath, "Terraform", "1.5.7")
+	if err = os.RemoveAll(installedPath); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	if err = os.MkdirAll(installedPath, 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	if err = os.WriteFile(filepath.Join(installedPath, lastUseFileName), []byte("2026-01-01"), 0o644); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if imported, err = Import(t.Context(), destConf, archivePath, sum, managers, false); err != nil || len(imported) != 1 {
+		t.Error("Expected incomplete installation to be replaced, get :", imported, err)
+	}
+
+	if _, err = os.Stat(filepath.Join(installedPath, "terraform")); err != nil {
+		t.Error("Unexpected error :", err)
+	}
+
+	if _, err = Import(t.Context(), destConf, archivePath, sum, map[string]versionmanager.VersionManager{}, false); !errors.Is(err, ErrUnknownTool) {
+		t.Error("Expected unknown tool error, get :", err)
+	}
+}
+
+func TestBundleUpstream(t *testing.T) {
+	t.Parallel()
+
+	sums := archiveSum + "  terraform_1.5.7_linux_amd64.zip\n"
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+		writer.Write([]byte(sums)) //nolint
+	}))
+	t.Cleanup(server.Close)
+
+	retriever := sumsRetriever{sumsURL: server.URL + "/terraform_1.5.7_SHA256SUMS"}
+	srcConf := testConf(t)
+	srcManager := versionmanager.Make(srcConf, "TFENV_", "Terraform", nil, retriever, nil)
+	versionPath := filepath.Join(srcConf.RootPath, "Terraform", "1.5.7")
+	if err := os.MkdirAll(versionPath, 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	if err := os.WriteFile(filepath.Join(versionPath, "terraform"), []byte("binary"), 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	if err := manifest.Write(versionPath, manifest.Manifest{ArchiveSHA256: archiveSum, Tool: "Terraform", Version: "1.5.7"}); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	items := []Item{{Manager: srcManager, Tool: "terraform", Version: "1.5.7"}, {Manager: srcManager, Tool: "terraform", Version: "1.5.7"}}
+	sum, err := Create(t.Context(), srcConf, archivePath, items)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	server.Close() // bundled upstream files are checked without network
+
+	destConf := testConf(t)
+	destConf.Offline = true
+	managers := map[string]versionmanager.VersionManager{"terraform": versionmanager.Make(destConf, "TFENV_", "Terraform", nil, retriever, nil)}
+	imported, err := Import(t.Context(), destConf, archivePath, sum, managers, false)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if len(imported) != 1 || len(imported[0].Upstream) != 1 {
+		t.Error("Unexpected imported entries :", imported)
+	}
+
+	// upstream checksum changed since installation
+	sums = "9a271f2a916b0b6ee6cecb2426f0b3206ef074578be55d9bc94f6f3fe3ab86aa  terraform_1.5.7_linux_amd64.zip\n"
+	stagingPath := t.TempDir()
+	if err = os.MkdirAll(filepath.Join(stagingPath, "terraform"), 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	if err = os.Rename(filepath.Join(destConf.RootPath, "Terraform", "1.5.7"), filepath.Join(stagingPath, "terraform", "1.5.7")); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	if err = os.MkdirAll(filepath.Join(stagingPath, upstreamDirName), 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	changedSum := sha256.Sum256([]byte(sums))
+	changedHex := hex.EncodeToString(changedSum[:])
+	if err = os.WriteFile(filepath.Join(stagingPath, upstreamDirName, changedHex), []byte(sums), 0o644); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	entry := imported[0]
+	for url := range entry.Upstream {
+		entry.Upstream[url] = changedHex
+	}
+	if err = verifyUpstream(t.Context(), destConf, stagingPath, entry, managers["terraform"]); !errors.Is(err, ErrUpstreamSum) {
+		t.Error("Expected upstream checksum error, get :", err)
+	}
+
+	for url := range entry.Upstream {
+		entry.Upstream[url] = archiveSum // no bundled file with this name
+	}
+	if err = verifyUpstream(t.Context(), destConf, stagingPath, entry, managers["terraform"]); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Expected missing file error, get :", err)
+	}
+}
+
+func TestImportDuplicate(t *testing.T) {
+	t.Parallel()
+
+	conf := testConf(t)
+	archivePath := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	archiveFile, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	gzipWriter := gzip.NewWriter(archiveFile)
+	tarWriter := tar.NewWriter(gzipWriter)
+	entry := `{"files": {}, "tool": "terraform", "version": "1.5.7"}`
+	if err = writeData(tarWriter, ManifestName, []byte(`{"entries": [`+entry+`, `+entry+`]}`), time.Now()); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	if err = errors.Join(tarWriter.Close(), gzipWriter.Close(), archiveFile.Close()); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	sum, err := sha256check.File(archivePath)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	managers := map[string]versionmanager.VersionManager{"terraform": versionmanager.Make(conf, "TFENV_", "Terraform", nil, nil, nil)}
+	if _, err = Import(t.Context(), conf, archivePath, sum, managers, false); !errors.Is(err, ErrDuplicate) {
+		t.Error("Expected duplicate error, get :", err)
+	}
+}
+
+func TestVerifyEntry(t *testing.T) {
+	t.Parallel()
+
+	stagingPath := t.TempDir()
+	versionPath := filepath.Join(stagingPath, "terraform", "1.5.7")
+	if err := os.MkdirAll(versionPath, 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	if err := os.WriteFile(filepath.Join(versionPath, "terraform"), []byte("tampered"), 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	managers := map[string]versionmanager.VersionManager{"terraform": {}}
+	entry := Entry{Files: map[string]string{"terraform": "9a271f2a916b0b6ee6cecb2426f0b3206ef074578be55d9bc94f6f3fe3ab86aa"}, Tool: "terraform", Version: "1.5.7"}
+	if err := verifyEntry(stagingPath, entry, managers); !errors.Is(err, ErrFileChecksum) {
+		t.Error("Expected file checksum error, get :", err)
+	}
+
+	entry.Version = "../1.5.7"
+	if err := verifyEntry(stagingPath, entry, managers); !errors.Is(err, ErrInvalidVersion) {
+		t.Error("Expected invalid version error, get :", err)
+	}
+}
+
+func testConf(t *testing.T) *config.Config {
+	t.Helper()
+
+	rootPath := t.TempDir()
+
+	return &config.Config{Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv, LockPath: rootPath, RootPath: rootPath}
+}
@@ -21,22 +21,28 @@ package versionmanager
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-hclog"
 	"github.com/hashicorp/go-version"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/download"
 	"github.com/tofuutils/tenv/v4/pkg/fileperm"
 	"github.com/tofuutils/tenv/v4/pkg/lockfile"
 	"github.com/tofuutils/tenv/v4/pkg/loghelper"
 	"github.com/tofuutils/tenv/v4/pkg/reversecmp"
 	"github.com/tofuutils/tenv/v4/versionmanager/lastuse"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
+	"github.com/tofuutils/tenv/v4/versionmanager/projectlock"
 	"github.com/tofuutils/tenv/v4/versionmanager/semantic"
 	versionfinder "github.com/tofuutils/tenv/v4/versionmanager/semantic/finder"
 	flatparser "github.com/tofuutils/tenv/v4/versionmanager/semantic/parser/flat"
@@ -46,9 +52,12 @@ import (
 
 var (
 	errEmptyVersion        = errors.New("empty version")
+	errInvalidVersion      = errors.New("invalid version")
 	errNoCompatible        = errors.New("no compatible version found")
+	ErrNoChecksum          = errors.New("release checksum retrieval not supported for this tool")
 	ErrNoCompatibleLocally = errors.New("no compatible version found locally")
 	ErrNoVersionFilesFound = errors.New("no version files found")
+	ErrNotInstalled        = errors.New("version not installed")
 )
 
 type ReleaseRetriever interface {
@@ -56,6 +65,17 @@ type ReleaseRetriever interface {
 	ListVersions(ctx context.Context) ([]string, error)
 }
 
+// Optional interface for a ReleaseRetriever, needed to pin release archive checksums in a project lock file.
+type ChecksumRetriever interface {
+	Checksum(ctx context.Context, version string, goos string, arch string) (string, error)
+}
+
+// Optional interface for a ReleaseRetriever, needed to record provenance in installed version manifest
+// (without it, the manifest records no validation).
+type ManifestRetriever interface {
+	InstallWithManifest(ctx context.Context, version string, targetPath string) (manifest.Manifest, error)
+}
+
 type DatedVersion struct {
 	UseDate time.Time
 	Version string
@@ -74,6 +94,16 @@ func Make(conf *config.Config, envPrefix string, folderName string, iacExts []ia
 	return VersionManager{Conf: conf, EnvNames: EnvPrefix(envPrefix), FolderName: folderName, iacExts: iacExts, retriever: retriever, VersionFiles: versionFiles}
 }
 
+// Return the release archive sha256 (hex encoded) for the given platform.
+func (m VersionManager) Checksum(ctx context.Context, version string, goos string, arch string) (string, error) {
+	checksumRetriever, ok := m.retriever.(ChecksumRetriever)
+	if !ok {
+		return "", ErrNoChecksum
+	}
+
+	return checksumRetriever.Checksum(ctx, version, goos, arch)
+}
+
 // Detect version (resolve and evaluate, can install depending on auto install env var).
 // When noFallback is true, returns ErrNoVersionFilesFound if no version files are found instead of using fallback strategy.
 func (m VersionManager) Detect(ctx context.Context, proxyCall bool, noFallback bool) (string, error) {
@@ -123,34 +153,33 @@ func (m VersionManager) Evaluate(ctx context.Context, requestedVersion string, p
 		return "", err
 	}
 
-	installPath, err := m.InstallPath()
-	if err != nil {
+	version, err := m.searchLocal(predicateInfo)
+	if err != nil || version != "" {
 		m.Conf.Displayer.Flush(proxyCall)
 
-		return "", err
+		return version, err
 	}
 
-	if !m.Conf.ForceRemote {
-		versions, err := m.innerListLocal(installPath, predicateInfo.ReverseOrder)
-		if err != nil {
-			m.Conf.Displayer.Flush(proxyCall)
-
-			return "", err
-		}
+	return m.searchInstallRemote(ctx, predicateInfo, m.Conf.SkipInstall, proxyCall)
+}
 
-		for _, version := range versions {
-			if predicateInfo.Predicate(version) {
-				m.Conf.Displayer.Display("Found compatible version installed locally : " + version)
-				m.Conf.Displayer.Flush(proxyCall)
+// Evaluate version resolution strategy or version constraint without installing anything (useful to pin an exact version).
+func (m VersionManager) EvaluateWithoutInstall(ctx context.Context, requestedVersion string) (string, error) {
+	if versionfinder.IsValid(requestedVersion) {
+		return versionfinder.Clean(requestedVersion), nil
+	}
 
-				return version, nil
-			}
-		}
+	predicateInfo, err := semantic.ParsePredicate(requestedVersion, m.FolderName, m, m.iacExts, m.Conf)
+	if err != nil {
+		return "", err
+	}
 
-		m.Conf.Displayer.Display("No compatible version found locally, search a remote one...")
+	version, err := m.searchLocal(predicateInfo)
+	if err != nil || version != "" {
+		return version, err
 	}
 
-	return m.searchInstallRemote(ctx, predicateInfo, m.Conf.SkipInstall, proxyCall)
+	return m.searchRemote(ctx, predicateInfo)
 }
 
 func (m VersionManager) Install(ctx context.Context, requestedVersion string) error {
@@ -171,24 +200,57 @@ func (m VersionManager) Install(ctx context.Context, requestedVersion string) er
 	return err
 }
 
+// Download and verify versions concurrently (bounded by Conf.InstallConc),
+// only the final move into install directory is done under lock.
 func (m VersionManager) InstallMultiple(ctx context.Context, versions []string) error {
 	installPath, err := m.InstallPath()
 	if err != nil {
 		return err
 	}
 
+	// load shared state before concurrent use
+	if err = m.Conf.InitRemoteConf(); err != nil {
+		return err
+	}
+	m.Conf.Displayer.Flush(false)
+
+	// clean once before concurrent installations (each one only locks to move its staged version)
 	deleteLock := lockfile.WriteWithCustomLockPath(m.Conf.LockPath, m.FolderName, m.Conf.Displayer)
 	disableExit := lockfile.CleanAndExitOnInterrupt(deleteLock)
-	defer disableExit()
-	defer deleteLock()
-
+	m.cleanStaleStaging(installPath)
+	disableExit()
+	deleteLock()
+
+	// lock file is polled each second, avoid that between goroutines
+	var moveMutex sync.Mutex
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(max(m.Conf.InstallConc, 1))
 	for _, version := range versions {
-		if err = m.installSpecificVersionWithoutLock(ctx, installPath, version, false); err != nil {
-			return err
-		}
+		group.Go(func() error {
+			return m.installStaged(groupCtx, installPath, version, &moveMutex)
+		})
 	}
 
-	return nil
+	return group.Wait()
+}
+
+// Read provenance manifest of an installed version.
+func (m VersionManager) Info(requestedVersion string) (manifest.Manifest, error) {
+	if !versionfinder.IsValid(requestedVersion) {
+		return manifest.Manifest{}, fmt.Errorf("%w : %s", errInvalidVersion, requestedVersion)
+	}
+
+	version := versionfinder.Clean(requestedVersion)
+	installPath, installed, err := m.checkVersionInstallation("", version)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	if !installed {
+		return manifest.Manifest{}, fmt.Errorf("%w : %s %s", ErrNotInstalled, m.FolderName, version)
+	}
+
+	return manifest.Read(filepath.Join(installPath, version))
 }
 
 // try to ensure the directory exists with a MkdirAll call.
@@ -221,7 +283,12 @@ func (m VersionManager) ListLocal(reverseOrder bool) ([]DatedVersion, error) {
 	return datedVersions, nil
 }
 
+// ListRemote fails with a download.OfflineError in offline mode (without calling the retriever).
 func (m VersionManager) ListRemote(ctx context.Context, reverseOrder bool) ([]string, error) {
+	if m.Conf.Offline {
+		return nil, download.OfflineError{Need: "listing remote versions of " + m.FolderName}
+	}
+
 	versions, err := m.retriever.ListVersions(ctx)
 	if err != nil {
 		return nil, err
@@ -250,8 +317,8 @@ func (m VersionManager) LocalSet() map[string]struct{} {
 
 	versionSet := make(map[string]struct{}, len(entries))
 	for _, entry := range entries {
-		if entry.IsDir() {
-			versionSet[entry.Name()] = struct{}{}
+		if entryName := entry.Name(); entry.IsDir() && !isHidden(entryName) && !IncompleteInstall(filepath.Join(installPath, entryName)) {
+			versionSet[entryName] = struct{}{}
 		}
 	}
 
@@ -312,6 +379,18 @@ func (m VersionManager) ResolveWithVersionFiles() (string, error) {
 	return semantic.RetrieveVersion(m.VersionFiles, m.Conf)
 }
 
+// Search the requested version in version files, ignoring project lock file.
+func (m VersionManager) ResolveWithoutLock() (string, error) {
+	versionFiles := make([]types.VersionFile, 0, len(m.VersionFiles))
+	for _, versionFile := range m.VersionFiles {
+		if versionFile.Name != projectlock.FileName {
+			versionFiles = append(versionFiles, versionFile)
+		}
+	}
+
+	return semantic.RetrieveVersion(versionFiles, m.Conf)
+}
+
 // (made lazy method : not always useful and allows flag override for root path).
 func (m VersionManager) RootConstraintFilePath() string {
 	return filepath.Join(m.Conf.RootPath, m.FolderName, "constraint")
@@ -342,6 +421,8 @@ func (m VersionManager) Uninstall(requestedVersion string) error {
 	defer disableExit()
 	defer deleteLock()
 
+	m.cleanStaleStaging(installPath)
+
 	if versionfinder.IsValid(requestedVersion) {
 		cleanedVersion := versionfinder.Clean(requestedVersion)
 		m.uninstallSpecificVersion(installPath, cleanedVersion)
@@ -445,7 +526,8 @@ func (m VersionManager) checkVersionInstallation(installPath string, version str
 		}
 	}
 
-	if _, err = os.Stat(filepath.Join(installPath, version)); err != nil {
+	versionPath := filepath.Join(installPath, version)
+	if _, err = os.Stat(versionPath); err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			return installPath, false, nil
 		}
@@ -453,7 +535,7 @@ func (m VersionManager) checkVersionInstallation(installPath string, version str
 		return "", false, err
 	}
 
-	return installPath, true, nil
+	return installPath, !IncompleteInstall(versionPath), nil
 }
 
 func (m VersionManager) innerListLocal(installPath string, reverseOrder bool) ([]string, error) {
@@ -464,8 +546,8 @@ func (m VersionManager) innerListLocal(installPath string, reverseOrder bool) ([
 
 	versions := make([]string, 0, len(entries))
 	for _, entry := range entries {
-		if entry.IsDir() {
-			versions = append(versions, entry.Name())
+		if entryName := entry.Name(); entry.IsDir() && !isHidden(entryName) && !IncompleteInstall(filepath.Join(installPath, entryName)) {
+			versions = append(versions, entryName)
 		}
 	}
 
@@ -475,6 +557,44 @@ func (m VersionManager) innerListLocal(installPath string, reverseOrder bool) ([
 	return versions, nil
 }
 
+// install in a staging directory without lock, then move it under lock.
+func (m VersionManager) installStaged(ctx context.Context, installPath string, version string, moveMutex *sync.Mutex) error {
+	if _, installed, err := m.checkVersionInstallation(installPath, version); err != nil || installed {
+		if installed {
+			m.Conf.Displayer.Display(loghelper.Concat(m.FolderName, " ", version, " already installed"))
+		}
+
+		return err
+	}
+
+	m.Conf.Displayer.Display(loghelper.Concat("Installing ", m.FolderName, " ", version))
+
+	stagingPath, release, err := m.stageVersion(ctx, installPath, version)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	moveMutex.Lock()
+	defer moveMutex.Unlock()
+
+	deleteLock := lockfile.WriteWithCustomLockPath(m.Conf.LockPath, m.FolderName, m.Conf.Displayer)
+	disableExit := lockfile.CleanAndExitOnInterrupt(deleteLock)
+	defer disableExit()
+	defer deleteLock()
+
+	// check again with lock, another process could have completed the same install
+	if _, installed, err := m.checkVersionInstallation(installPath, version); err != nil || installed {
+		if installed {
+			m.Conf.Displayer.Display(loghelper.Concat(m.FolderName, " ", version, " already installed"))
+		}
+
+		return err
+	}
+
+	return m.moveStaged(stagingPath, installPath, version)
+}
+
 func (m VersionManager) installSpecificVersion(ctx context.Context, version string, proxyCall bool) error {
 	if version == "" {
 		m.Conf.Displayer.Flush(proxyCall)
@@ -519,33 +639,75 @@ func (m VersionManager) installSpecificVersionWithoutLock(ctx context.Context, i
 	m.Conf.Displayer.Flush(false)
 	m.Conf.Displayer.Display(loghelper.Concat("Installing ", m.FolderName, " ", version))
 
-	err = m.retriever.Install(ctx, version, filepath.Join(installPath, version))
-	if err == nil {
-		m.Conf.Displayer.Display(loghelper.Concat("Installation of ", m.FolderName, " ", version, " successful"))
+	m.cleanStaleStaging(installPath)
+
+	stagingPath, release, err := m.stageVersion(ctx, installPath, version)
+	if err != nil {
+		return err
 	}
+	defer release()
 
-	return err
+	return m.moveStaged(stagingPath, installPath, version)
 }
 
 func (m VersionManager) searchInstallRemote(ctx context.Context, predicateInfo types.PredicateInfo, noInstall bool, proxyCall bool) (string, error) {
-	versions, err := m.ListRemote(ctx, predicateInfo.ReverseOrder)
+	version, err := m.searchRemote(ctx, predicateInfo)
 	if err != nil {
 		m.Conf.Displayer.Flush(proxyCall)
 
 		return "", err
 	}
 
+	if noInstall {
+		return version, m.autoInstallDisabledMsg(version)
+	}
+
+	return version, m.installSpecificVersion(ctx, version, proxyCall)
+}
+
+// return the first installed version matching predicate, or an empty string when none match
+// (or when remote search is forced).
+func (m VersionManager) searchLocal(predicateInfo types.PredicateInfo) (string, error) {
+	if m.Conf.ForceRemote && !m.Conf.Offline {
+		return "", nil
+	}
+
+	installPath, err := m.InstallPath()
+	if err != nil {
+		return "", err
+	}
+
+	versions, err := m.innerListLocal(installPath, predicateInfo.ReverseOrder)
+	if err != nil {
+		return "", err
+	}
+
+	for _, version := range versions {
+		if predicateInfo.Predicate(version) {
+			m.Conf.Displayer.Display("Found compatible version installed locally : " + version)
+
+			return version, nil
+		}
+	}
+
+	m.Conf.Displayer.Display("No compatible version found locally, search a remote one...")
+
+	return "", nil
+}
+
+func (m VersionManager) searchRemote(ctx context.Context, predicateInfo types.PredicateInfo) (string, error) {
+	versions, err := m.ListRemote(ctx, predicateInfo.ReverseOrder)
+	if err != nil {
+		return "", err
+	}
+
 	for _, version := range versions {
 		if predicateInfo.Predicate(version) {
 			m.Conf.Displayer.Display("Found compatible version remotely : " + version)
-			if noInstall {
-				return version, m.autoInstallDisabledMsg(version)
-			}
 
-			return version, m.installSpecificVersion(ctx, version, proxyCall)
+			return version, nil
 		}
 	}
-	m.Conf.Displayer.Flush(proxyCall)
 
 	return "", errNoCompatible
 }
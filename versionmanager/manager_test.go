@@ -0,0 +1,314 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package versionmanager_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/versionmanager"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
+)
+
+type countingRetriever struct {
+	calls *int
+}
+
+func (r countingRetriever) Install(context.Context, string, string) error {
+	*r.calls++
+
+	return nil
+}
+
+func (r countingRetriever) ListVersions(context.Context) ([]string, error) {
+	*r.calls++
+
+	return []string{"1.5.7", "1.6.0"}, nil
+}
+
+type parallelRetriever struct {
+	maxRunning *atomic.Int32
+	running    *atomic.Int32
+}
+
+func (r parallelRetriever) Install(_ context.Context, version string, targetPath string) error {
+	running := r.running.Add(1)
+	defer r.running.Add(-1)
+
+	for current := r.maxRunning.Load(); running > current && !r.maxRunning.CompareAndSwap(current, running); {
+		current = r.maxRunning.Load()
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	return os.WriteFile(filepath.Join(targetPath, "terraform"), []byte(version), 0o755)
+}
+
+func (r parallelRetriever) ListVersions(context.Context) ([]string, error) {
+	return nil, nil
+}
+
+// simulate a tool checksum retrieval in offline mode.
+type offlineSumRetriever struct {
+	parallelRetriever
+}
+
+func (r offlineSumRetriever) Checksum(context.Context, string, string, string) (string, error) {
+	return "", download.OfflineError{Need: "checksums download"}
+}
+
+func TestInstallMultiple(t *testing.T) {
+	t.Parallel()
+
+	rootPath := t.TempDir()
+	stalePath := filepath.Join(rootPath, "Terraform", ".install-stale")
+	if err := os.MkdirAll(stalePath, 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	oldTime := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(stalePath, oldTime, oldTime); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	var maxRunning, running atomic.Int32
+	conf := &config.Config{Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv, InstallConc: 2, LockPath: rootPath, RootPath: rootPath}
+	manager := versionmanager.Make(conf, "TFENV_", "Terraform", nil, parallelRetriever{maxRunning: &maxRunning, running: &running}, nil)
+
+	versions := []string{"1.5.5", "1.5.6", "1.5.7", "1.6.0"}
+	if err := manager.InstallMultiple(context.Background(), versions); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if current := maxRunning.Load(); current != 2 {
+		t.Error("Expected 2 parallel installations, get :", current)
+	}
+
+	if _, err := os.Stat(stalePath); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Expected stale staging directory removal, get :", err)
+	}
+
+	datedVersions, err := manager.ListLocal(false)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	installed := make([]string, 0, len(datedVersions))
+	for _, datedVersion := range datedVersions {
+		installed = append(installed, datedVersion.Version)
+	}
+
+	if !slices.Equal(installed, versions) {
+		t.Error("Unexpected installed versions (staging directories should not be listed) :", installed)
+	}
+
+	data, err := os.ReadFile(filepath.Join(rootPath, "Terraform", "1.5.7", "terraform"))
+	if err != nil || string(data) != "1.5.7" {
+		t.Error("Unexpected installed content :", string(data), err)
+	}
+}
+
+func TestInstallRepair(t *testing.T) {
+	t.Parallel()
+
+	rootPath := t.TempDir()
+	installPath := filepath.Join(rootPath, "Terraform")
+	stalePath, hiddenPath := filepath.Join(installPath, ".install-stale"), filepath.Join(installPath, ".hidden")
+	for _, dirPath := range []string{filepath.Join(installPath, "1.5.7"), stalePath, hiddenPath} {
+		if err := os.MkdirAll(dirPath, 0o755); err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+	}
+
+	oldTime := time.Now().Add(-2 * time.Hour)
+	for _, dirPath := range []string{stalePath, hiddenPath} {
+		if err := os.Chtimes(dirPath, oldTime, oldTime); err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+	}
+
+	var maxRunning, running atomic.Int32
+	conf := &config.Config{Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv, LockPath: rootPath, RootPath: rootPath}
+	manager := versionmanager.Make(conf, "TFENV_", "Terraform", nil, parallelRetriever{maxRunning: &maxRunning, running: &running}, nil)
+
+	if datedVersions, err := manager.ListLocal(false); err != nil || len(datedVersions) != 0 {
+		t.Error("Expected incomplete installation to be ignored, get :", datedVersions, err)
+	}
+
+	if err := manager.Install(context.Background(), "1.5.7"); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if maxRunning.Load() != 1 {
+		t.Error("Expected incomplete installation to be replaced")
+	}
+
+	if _, err := os.Stat(filepath.Join(installPath, "1.5.7", versionmanager.CompleteMarkerName)); err != nil {
+		t.Error("Expected completion marker, get :", err)
+	}
+
+	if _, err := os.Stat(stalePath); !errors.Is(err, os.ErrNotExist) {
+		t.Error("Expected stale staging directory removal, get :", err)
+	}
+
+	if _, err := os.Stat(hiddenPath); err != nil {
+		t.Error("Expected other hidden directory to be kept, get :", err)
+	}
+}
+
+type manifestRetriever struct {
+	parallelRetriever
+}
+
+func (r manifestRetriever) InstallWithManifest(ctx context.Context, version string, targetPath string) (manifest.Manifest, error) {
+	installManifest := manifest.Manifest{ArchiveSHA256: "0123", SignatureIdentity: manifest.PGPIdentity("abcd"), SourceURL: "https://example.com/terraform.zip", Validation: config.SignValidation}
+
+	return installManifest, r.Install(ctx, version, targetPath)
+}
+
+func TestInfo(t *testing.T) {
+	t.Parallel()
+
+	var maxRunning, running atomic.Int32
+	retriever := parallelRetriever{maxRunning: &maxRunning, running: &running}
+	for _, testCase := range []struct {
+		name       string
+		retriever  versionmanager.ReleaseRetriever
+		identity   string
+		validation config.ValidationMode
+	}{
+		{name: "basic", retriever: retriever, validation: config.UnknownValidation},
+		{name: "manifest", retriever: manifestRetriever{parallelRetriever: retriever}, identity: "pgp:abcd", validation: config.SignValidation},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootPath := t.TempDir()
+			conf := &config.Config{Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv, LockPath: rootPath, RootPath: rootPath, TenvVersion: "v4.0.0-test"}
+			manager := versionmanager.Make(conf, "TFENV_", "Terraform", nil, testCase.retriever, nil)
+
+			if _, err := manager.Info("1.5.7"); !errors.Is(err, versionmanager.ErrNotInstalled) {
+				t.Error("Expected not installed error, get :", err)
+			}
+
+			if err := manager.Install(context.Background(), "v1.5.7"); err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			installManifest, err := manager.Info("1.5.7")
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if installManifest.Validation != testCase.validation || installManifest.SignatureIdentity != testCase.identity || installManifest.TenvVersion != "v4.0.0-test" || installManifest.Version != "1.5.7" || installManifest.InstalledAt.IsZero() {
+				t.Error("Unexpected manifest :", installManifest)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	rootPath := t.TempDir()
+	var maxRunning, running atomic.Int32
+	conf := &config.Config{Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv, LockPath: rootPath, RootPath: rootPath}
+	manager := versionmanager.Make(conf, "TFENV_", "Terraform", nil, parallelRetriever{maxRunning: &maxRunning, running: &running}, nil)
+
+	ctx := context.Background()
+	if err := manager.Install(ctx, "1.5.7"); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err := manager.Verify(ctx, "1.5.7", false); err != nil {
+		t.Error("Unexpected error :", err)
+	}
+
+	if err := manager.Verify(ctx, "1.5.7", true); !errors.Is(err, versionmanager.ErrNoChecksum) || !versionmanager.IsUnverifiable(err) {
+		t.Error("Expected unverifiable no checksum error, get :", err)
+	}
+
+	offlineManager := versionmanager.Make(conf, "TFENV_", "Terraform", nil, offlineSumRetriever{parallelRetriever: parallelRetriever{maxRunning: &maxRunning, running: &running}}, nil)
+	if err := offlineManager.Verify(ctx, "1.5.7", true); !errors.Is(err, versionmanager.ErrUpstreamUnreachable) || !versionmanager.IsUnverifiable(err) {
+		t.Error("Expected unverifiable unreachable upstream error, get :", err)
+	}
+
+	versionPath := filepath.Join(rootPath, "Terraform", "1.5.7")
+	if err := os.WriteFile(filepath.Join(versionPath, "terraform"), []byte("tampered"), 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err := manager.Verify(ctx, "1.5.7", false); !errors.Is(err, versionmanager.ErrTampered) {
+		t.Error("Expected tampered error, get :", err)
+	}
+
+	if err := os.Remove(filepath.Join(versionPath, manifest.FileName)); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err := manager.Verify(ctx, "1.5.7", false); !versionmanager.IsUnverifiable(err) {
+		t.Error("Expected unverifiable error, get :", err)
+	}
+}
+
+func TestOffline(t *testing.T) {
+	t.Parallel()
+
+	rootPath := t.TempDir()
+	versionPath := filepath.Join(rootPath, "Terraform", "1.5.7")
+	if err := os.MkdirAll(versionPath, 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	if err := os.WriteFile(filepath.Join(versionPath, "terraform"), nil, 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	calls := 0
+	conf := &config.Config{Displayer: loghelper.InertDisplayer, ForceRemote: true, Getenv: config.EmptyGetenv, Offline: true, RootPath: rootPath, SkipInstall: true, WorkPath: rootPath}
+	manager := versionmanager.Make(conf, "TFENV_", "Terraform", nil, countingRetriever{calls: &calls}, nil)
+
+	ctx := context.Background()
+	version, err := manager.Evaluate(ctx, "~> 1.5.0", false)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	if version != "1.5.7" {
+		t.Error("Unexpected version :", version)
+	}
+
+	if _, err = manager.Evaluate(ctx, ">= 1.6", false); !errors.Is(err, download.ErrOffline) {
+		t.Error("Expected offline error, get :", err)
+	}
+
+	if _, err = manager.ListRemote(ctx, true); !errors.Is(err, download.ErrOffline) {
+		t.Error("Expected offline error, get :", err)
+	}
+
+	if calls != 0 {
+		t.Error("Expected no retriever call, get :", calls)
+	}
+}
@@ -0,0 +1,169 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package manifest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"time"
+
+	"github.com/tofuutils/tenv/v4/config"
+	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
+	"github.com/tofuutils/tenv/v4/pkg/fileperm"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+const (
+	FileName = "manifest.json"
+
+	modulePath     = "github.com/tofuutils/tenv/v4"
+	unknownVersion = "unknown"
+)
+
+var ErrNoManifest = errors.New("no manifest for this version (installed before manifest introduction or imported from an older bundle)")
+
+// Provenance of an installed version. Validation is the mode really applied (can be weaker than the configured one,
+// for example when no signature is available), SignatureIdentity is empty when no signature was checked.
+type Manifest struct {
+	ArchiveSHA256     string                `json:"archive_sha256,omitempty"`
+	Files             map[string]string     `json:"files,omitempty"` // relative path to sha256 (hex encoded)
+	InstalledAt       time.Time             `json:"installed_at"`
+	SignatureIdentity string                `json:"signature_identity,omitempty"`
+	SourceURL         string                `json:"source_url,omitempty"`
+	TenvVersion       string                `json:"tenv_version"`
+	Tool              string                `json:"tool"`
+	Validation        config.ValidationMode `json:"validation"`
+	Version           string                `json:"version"`
+}
+
+// ChecksSummary describes the checks really done during installation.
+func (m Manifest) ChecksSummary() string {
+	switch m.Validation {
+	case config.AttestationValidation:
+		return "sha256 checksum and build provenance attestation verified (built by " + m.SignatureIdentity + ")"
+	case config.SignValidation:
+		if m.SignatureIdentity == "" {
+			return "sha256 checksum and signature verified"
+		}
+
+		return "sha256 checksum and signature verified (signed by " + m.SignatureIdentity + ")"
+	case config.SignNoTlogValidation:
+		return "sha256 checksum and signature verified without transparency log inclusion (signed by " + m.SignatureIdentity + ")"
+	case config.ShaValidation:
+		return "sha256 checksum verified, signature not checked"
+	case config.UnknownValidation:
+		return "unknown (checks done are not recorded)"
+	default:
+		return "none (checksum and signature not checked)"
+	}
+}
+
+func AttestationIdentity(buildSignerURI string) string {
+	return "attestation:" + buildSignerURI
+}
+
+func CosignIdentity(identity string, issuer string) string {
+	return loghelper.Concat("cosign:", identity, " (issuer ", issuer, ")")
+}
+
+// HashFiles returns sha256 (hex encoded) of each file in versionPath, except ignored names at its top level.
+func HashFiles(versionPath string, ignored ...string) (map[string]string, error) {
+	sums := map[string]string{}
+	err := filepath.WalkDir(versionPath, func(filePath string, dirEntry fs.DirEntry, err error) error {
+		if err != nil || dirEntry.IsDir() {
+			return err
+		}
+
+		relPath, err := filepath.Rel(versionPath, filePath)
+		if err != nil || slices.Contains(ignored, relPath) {
+			return err
+		}
+
+		sum, err := sha256check.File(filePath)
+		if err == nil {
+			sums[filepath.ToSlash(relPath)] = hex.EncodeToString(sum)
+		}
+
+		return err
+	})
+
+	return sums, err
+}
+
+func PGPIdentity(fingerprint string) string {
+	return "pgp:" + fingerprint
+}
+
+func Read(versionPath string) (Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(versionPath, FileName))
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Manifest{}, ErrNoManifest
+		}
+
+		return Manifest{}, err
+	}
+
+	manifest := Manifest{Validation: config.UnknownValidation} // when missing
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, fmt.Errorf("invalid %s : %w", FileName, err)
+	}
+
+	return manifest, nil
+}
+
+// TenvVersion returns configured version or the one found in build information.
+func TenvVersion(conf *config.Config) string {
+	if conf.TenvVersion != "" {
+		return conf.TenvVersion
+	}
+
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return unknownVersion
+	}
+
+	if buildInfo.Main.Path == modulePath {
+		return buildInfo.Main.Version
+	}
+
+	for _, dep := range buildInfo.Deps { // used as a library
+		if dep.Path == modulePath {
+			return dep.Version
+		}
+	}
+
+	return unknownVersion
+}
+
+func Write(versionPath string, manifest Manifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(versionPath, FileName), data, fileperm.RW)
+}
@@ -0,0 +1,163 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package projectlock
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/fileperm"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	versionfinder "github.com/tofuutils/tenv/v4/versionmanager/semantic/finder"
+	"github.com/tofuutils/tenv/v4/versionmanager/semantic/types"
+)
+
+const FileName = ".tenv.lock"
+
+var ErrChecksum = errors.New("release archive checksum does not match the one pinned in " + FileName)
+
+type ToolLock struct {
+	Version   string            `json:"version"`
+	Checksums map[string]string `json:"checksums,omitempty"` // key is a platform (see PlatformKey)
+}
+
+type Lock struct {
+	Tools map[string]ToolLock `json:"tools"`
+}
+
+func PlatformKey(goos string, arch string) string {
+	return goos + "_" + arch
+}
+
+// Check that sha256 sum match the one pinned in nearest project lock file (no check when nothing is pinned).
+func Check(conf *config.Config, toolName string, version string, goos string, arch string, sum []byte) error {
+	filePath, lock, err := Search(conf)
+	if err != nil || filePath == "" {
+		return err
+	}
+
+	toolLock, ok := lock.Tools[toolName]
+	if !ok || !sameVersion(toolLock.Version, version) {
+		conf.Displayer.Log(hclog.Debug, "No pinned version in lock file", "tool", toolName, "version", version, "filePath", filePath)
+
+		return nil
+	}
+
+	platform := PlatformKey(goos, arch)
+	expected := toolLock.Checksums[platform]
+	if expected == "" {
+		conf.Displayer.Log(hclog.Warn, "No pinned checksum in lock file for current platform", "tool", toolName, "platform", platform, "filePath", filePath)
+
+		return nil
+	}
+
+	if hex.EncodeToString(sum) != expected {
+		return fmt.Errorf("%w (%s %s for %s)", ErrChecksum, toolName, version, platform)
+	}
+
+	conf.Displayer.Display(loghelper.Concat("Checksum pinned in ", filePath, " verified"))
+
+	return nil
+}
+
+// return an empty Lock (no error) when the file does not exist.
+func Read(filePath string) (Lock, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return Lock{Tools: map[string]ToolLock{}}, nil
+		}
+
+		return Lock{}, err
+	}
+
+	var lock Lock
+	if err = json.Unmarshal(data, &lock); err != nil {
+		return Lock{}, fmt.Errorf("invalid %s file : %w", filePath, err)
+	}
+
+	if lock.Tools == nil {
+		lock.Tools = map[string]ToolLock{}
+	}
+
+	return lock, nil
+}
+
+// Search the nearest project lock file (in working directory or its parents), return an empty path when none are found.
+func Search(conf *config.Config) (string, Lock, error) {
+	previousPath, err := filepath.Abs(conf.WorkPath)
+	if err != nil {
+		return "", Lock{}, err
+	}
+
+	for currentPath := previousPath; ; previousPath, currentPath = currentPath, filepath.Dir(currentPath) {
+		filePath := filepath.Join(currentPath, FileName)
+		if _, err = os.Stat(filePath); err == nil {
+			lock, err := Read(filePath)
+
+			return filePath, lock, err
+		}
+
+		if currentPath == filepath.Dir(currentPath) {
+			return "", Lock{}, nil
+		}
+	}
+}
+
+// VersionFile return a version file description, allowing project lock file to take part in version resolution.
+func VersionFile(toolName string) types.VersionFile {
+	return types.VersionFile{Name: FileName, Parser: func(filePath string, conf *config.Config) (string, error) {
+		lock, err := Read(filePath)
+		if err != nil {
+			return "", err
+		}
+
+		toolLock, ok := lock.Tools[toolName]
+		if !ok || toolLock.Version == "" {
+			return "", nil
+		}
+
+		return types.DisplayDetectionInfo(conf.Displayer, toolLock.Version, filePath), nil
+	}}
+}
+
+func Write(filePath string, lock Lock) error {
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filePath, append(data, '\n'), fileperm.RW)
+}
+
+func sameVersion(lockedVersion string, version string) bool {
+	if !versionfinder.IsValid(lockedVersion) || !versionfinder.IsValid(version) {
+		return lockedVersion == version
+	}
+
+	return versionfinder.Clean(lockedVersion) == versionfinder.Clean(version)
+}
@@ -0,0 +1,115 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package projectlock
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/config/cmdconst"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+var archiveSum = sha256.Sum256([]byte("archive content"))
+
+func TestProjectLock(t *testing.T) {
+	t.Parallel()
+
+	rootPath := t.TempDir()
+	workPath := filepath.Join(rootPath, "sub", "dir")
+	if err := os.MkdirAll(workPath, 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	lock := Lock{Tools: map[string]ToolLock{
+		cmdconst.TerraformName: {Version: "1.5.7", Checksums: map[string]string{
+			PlatformKey("linux", "amd64"): hex.EncodeToString(archiveSum[:]),
+		}},
+	}}
+	if err := Write(filepath.Join(rootPath, FileName), lock); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	conf := &config.Config{Displayer: loghelper.InertDisplayer, WorkPath: workPath}
+
+	t.Run("Search", func(t *testing.T) {
+		t.Parallel()
+
+		filePath, readLock, err := Search(conf)
+		if err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+		if filePath != filepath.Join(rootPath, FileName) {
+			t.Error("Unexpected lock file path :", filePath)
+		}
+		if readLock.Tools[cmdconst.TerraformName].Version != "1.5.7" {
+			t.Error("Unexpected lock content :", readLock)
+		}
+	})
+
+	t.Run("VersionFile", func(t *testing.T) {
+		t.Parallel()
+
+		versionFile := VersionFile(cmdconst.TerraformName)
+		version, err := versionFile.Parser(filepath.Join(rootPath, FileName), conf)
+		if err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+		if version != "1.5.7" {
+			t.Error("Unexpected version :", version)
+		}
+
+		versionFile = VersionFile(cmdconst.TofuName)
+		if version, err = versionFile.Parser(filepath.Join(rootPath, FileName), conf); err != nil || version != "" {
+			t.Error("Unexpected result for unlocked tool :", version, err)
+		}
+	})
+
+	t.Run("CheckMatch", func(t *testing.T) {
+		t.Parallel()
+
+		if err := Check(conf, cmdconst.TerraformName, "v1.5.7", "linux", "amd64", archiveSum[:]); err != nil {
+			t.Error("Unexpected error :", err)
+		}
+	})
+
+	t.Run("CheckMismatch", func(t *testing.T) {
+		t.Parallel()
+
+		if err := Check(conf, cmdconst.TerraformName, "1.5.7", "linux", "amd64", []byte("tampered sum")); !errors.Is(err, ErrChecksum) {
+			t.Error("Expected checksum error, get :", err)
+		}
+	})
+
+	t.Run("CheckNotPinned", func(t *testing.T) {
+		t.Parallel()
+
+		if err := Check(conf, cmdconst.TerraformName, "1.6.0", "linux", "amd64", []byte("other sum")); err != nil {
+			t.Error("Unexpected error :", err)
+		}
+		if err := Check(conf, cmdconst.TerraformName, "1.5.7", "darwin", "arm64", []byte("other sum")); err != nil {
+			t.Error("Unexpected error :", err)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package providermirror
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
+)
+
+const (
+	h1Prefix = "h1:"
+	zhPrefix = "zh:"
+)
+
+// HashDir computes the "h1:" hash of an unpacked provider directory (as recorded in dependency lock files).
+func HashDir(dirPath string) (string, error) {
+	var names []string
+	err := filepath.WalkDir(dirPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		name, err := filepath.Rel(dirPath, path)
+		if err != nil {
+			return err
+		}
+		names = append(names, filepath.ToSlash(name))
+
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	slices.Sort(names)
+
+	summary := sha256.New()
+	for _, name := range names {
+		if strings.Contains(name, "\n") {
+			return "", fmt.Errorf("%w : file name with newline %q", ErrChecksum, name)
+		}
+
+		fileSum, err := sha256check.File(filepath.Join(dirPath, filepath.FromSlash(name)))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(summary, "%x  %s\n", fileSum, name)
+	}
+
+	return h1Prefix + base64.StdEncoding.EncodeToString(summary.Sum(nil)), nil
+}
+
+// ZipHash returns the "zh:" hash of a provider archive from its sha256.
+func ZipHash(sha256Sum []byte) string {
+	return zhPrefix + hex.EncodeToString(sha256Sum)
+}
@@ -0,0 +1,203 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package providermirror
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+
+	iacparser "github.com/tofuutils/tenv/v4/versionmanager/semantic/parser/iac"
+)
+
+const (
+	DefaultHost  = "registry.terraform.io"
+	LockFileName = ".terraform.lock.hcl"
+
+	builtinHost      = "terraform.io"
+	builtinNamespace = "builtin"
+	defaultNamespace = "hashicorp"
+	providerName     = "provider"
+)
+
+var ErrAddress = errors.New("invalid provider address")
+
+var lockSchema = &hcl.BodySchema{ //nolint
+	Blocks: []hcl.BlockHeaderSchema{{Type: providerName, LabelNames: []string{"source"}}},
+}
+
+var lockProviderSchema = &hcl.BodySchema{ //nolint
+	Attributes: []hcl.AttributeSchema{{Name: "constraints"}, {Name: "hashes"}, {Name: "version"}},
+}
+
+// Address is a fully qualified provider source address.
+type Address struct {
+	Host      string
+	Namespace string
+	Type      string
+}
+
+// ParseAddress parses a provider source address, its host and namespace are optional ("hashicorp" is the default namespace).
+func ParseAddress(source string, defaultHost string) (Address, error) {
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(source)), "/")
+	if slices.Contains(parts, "") {
+		return Address{}, fmt.Errorf("%w : %q", ErrAddress, source)
+	}
+
+	switch len(parts) {
+	case 1:
+		return Address{Host: defaultHost, Namespace: defaultNamespace, Type: parts[0]}, nil
+	case 2:
+		return Address{Host: defaultHost, Namespace: parts[0], Type: parts[1]}, nil
+	case 3:
+		return Address{Host: parts[0], Namespace: parts[1], Type: parts[2]}, nil
+	}
+
+	return Address{}, fmt.Errorf("%w : %q", ErrAddress, source)
+}
+
+func (a Address) String() string {
+	return a.Host + "/" + a.Namespace + "/" + a.Type
+}
+
+// Provider describes a provider to mirror, Version and Hashes come from lock file (empty when not locked).
+type Provider struct {
+	Address     Address
+	Constraints []string
+	Hashes      []string
+	Version     string
+}
+
+// Collect merges locked providers with requirements from required_providers blocks (built-in providers are skipped), sorted by address.
+func Collect(requirements []iacparser.ProviderRequirement, locked []Provider, defaultHost string) ([]Provider, error) {
+	providers := slices.Clone(locked)
+	for _, requirement := range requirements {
+		source := requirement.Source
+		if source == "" {
+			source = requirement.Name
+		}
+
+		address, err := ParseAddress(source, defaultHost)
+		if err != nil {
+			return nil, err
+		}
+
+		if address.Host == builtinHost && address.Namespace == builtinNamespace {
+			continue
+		}
+
+		index := slices.IndexFunc(providers, func(provider Provider) bool {
+			return provider.Address == address
+		})
+		if index == -1 {
+			index = len(providers)
+			providers = append(providers, Provider{Address: address})
+		}
+
+		if requirement.Version != "" && !slices.Contains(providers[index].Constraints, requirement.Version) {
+			providers[index].Constraints = append(providers[index].Constraints, requirement.Version)
+		}
+	}
+
+	slices.SortFunc(providers, func(a Provider, b Provider) int {
+		return strings.Compare(a.Address.String(), b.Address.String())
+	})
+
+	return providers, nil
+}
+
+// ReadLockFile returns providers pinned in a dependency lock file (nothing when the file does not exist).
+func ReadLockFile(filePath string) ([]Provider, error) {
+	if _, err := os.Stat(filePath); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	parsedFile, diags := hclparse.NewParser().ParseHCLFile(filePath)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	content, _, diags := parsedFile.Body.PartialContent(lockSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	providers := make([]Provider, 0, len(content.Blocks))
+	for _, block := range content.Blocks {
+		address, err := ParseAddress(block.Labels[0], DefaultHost)
+		if err != nil {
+			return nil, err
+		}
+
+		attrs, _, diags := block.Body.PartialContent(lockProviderSchema)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+
+		provider := Provider{Address: address}
+		if err = decodeAttr(attrs.Attributes, "version", cty.String, &provider.Version); err != nil {
+			return nil, err
+		}
+
+		var constraints string
+		if err = decodeAttr(attrs.Attributes, "constraints", cty.String, &constraints); err != nil {
+			return nil, err
+		}
+
+		if constraints != "" {
+			provider.Constraints = []string{constraints}
+		}
+
+		if err = decodeAttr(attrs.Attributes, "hashes", cty.List(cty.String), &provider.Hashes); err != nil {
+			return nil, err
+		}
+
+		providers = append(providers, provider)
+	}
+
+	return providers, nil
+}
+
+// missing attribute is not an error (target is left unchanged).
+func decodeAttr(attrs hcl.Attributes, name string, wantType cty.Type, target any) error {
+	attr, ok := attrs[name]
+	if !ok {
+		return nil
+	}
+
+	val, diags := attr.Expr.Value(nil)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	val, err := convert.Convert(val, wantType)
+	if err != nil || val.IsNull() {
+		return err
+	}
+
+	return gocty.FromCtyValue(val, target)
+}
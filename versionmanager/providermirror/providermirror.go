@@ -0,0 +1,301 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package providermirror
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/go-version"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/fileperm"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/pkg/uncompress/zip"
+)
+
+const (
+	discoveryPath = "/.well-known/terraform.json"
+	providersKey  = "providers.v1"
+)
+
+var (
+	ErrChecksum  = errors.New("provider archive checksum mismatch")
+	ErrLockHash  = errors.New("provider hashes do not match lock file")
+	ErrNoVersion = errors.New("no provider version matching constraints")
+	ErrRegistry  = errors.New("provider registry failure")
+)
+
+// Result describes a mirrored provider.
+type Result struct {
+	Address Address
+	Hash    string // "h1:" hash of the unpacked provider
+	Path    string
+	Skipped bool // already present in mirror directory
+	Version string
+}
+
+type downloadInfo struct {
+	DownloadURL string `json:"download_url"`
+	Filename    string `json:"filename"`
+	Shasum      string `json:"shasum"`
+}
+
+type versionsInfo struct {
+	Versions []struct {
+		Platforms []struct {
+			Arch string `json:"arch"`
+			OS   string `json:"os"`
+		} `json:"platforms"`
+		Version string `json:"version"`
+	} `json:"versions"`
+}
+
+type mirror struct {
+	baseURLs map[string]string
+	conf     *config.Config
+	dirPath  string
+	goos     string
+	arch     string
+}
+
+// Mirror downloads providers for goos and arch from their registry, unpacked in dirPath with the layout expected in TF_PLUGIN_CACHE_DIR
+// (<host>/<namespace>/<type>/<version>/<os>_<arch>). Archives are checked against registry checksum and lock file hashes (when locked).
+func Mirror(ctx context.Context, conf *config.Config, providers []Provider, dirPath string, goos string, arch string) ([]Result, error) {
+	// use Terraform remote retry policy
+	ctx, err := conf.DownloadContext(ctx, conf.Tf)
+	if err != nil {
+		return nil, err
+	}
+
+	m := mirror{baseURLs: map[string]string{}, conf: conf, dirPath: dirPath, goos: goos, arch: arch}
+	results := make([]Result, 0, len(providers))
+	for _, provider := range providers {
+		result, err := m.install(ctx, provider)
+		if err != nil {
+			return results, fmt.Errorf("%w : %s", err, provider.Address)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (m mirror) install(ctx context.Context, provider Provider) (Result, error) {
+	baseURL, err := m.providersURL(ctx, provider.Address.Host)
+	if err != nil {
+		return Result{}, err
+	}
+
+	versionStr := provider.Version
+	if versionStr == "" {
+		if versionStr, err = m.selectVersion(ctx, baseURL, provider); err != nil {
+			return Result{}, err
+		}
+	}
+
+	result := Result{Address: provider.Address, Version: versionStr}
+	result.Path = filepath.Join(m.dirPath, provider.Address.Host, provider.Address.Namespace, provider.Address.Type, versionStr, m.goos+"_"+m.arch)
+	if hash, err := HashDir(result.Path); err == nil && (len(provider.Hashes) == 0 || slices.Contains(provider.Hashes, hash)) {
+		m.conf.Displayer.Log(hclog.Debug, "Provider already mirrored", "provider", provider.Address, "version", versionStr)
+		result.Hash, result.Skipped = hash, true
+
+		return result, nil
+	}
+
+	downloadURL, err := url.JoinPath(baseURL, provider.Address.Namespace, provider.Address.Type, versionStr, "download", m.goos, m.arch)
+	if err != nil {
+		return Result{}, err
+	}
+
+	var info downloadInfo
+	if err = m.getJSON(ctx, downloadURL, &info); err != nil {
+		return Result{}, err
+	}
+
+	archiveURL, err := resolveURL(downloadURL, info.DownloadURL)
+	if err != nil {
+		return Result{}, err
+	}
+
+	file, err := m.conf.DownloadCache().File(ctx, archiveURL, m.conf.Displayer.Display, loghelper.ProgressFunc(m.conf.Displayer), checkStatus)
+	if err != nil {
+		return Result{}, err
+	}
+	defer file.Release()
+
+	if expected, err := hex.DecodeString(info.Shasum); err != nil || !slices.Equal(expected, file.SHA256) {
+		return Result{}, fmt.Errorf("%w : %s", ErrChecksum, info.Filename)
+	}
+
+	if result.Hash, err = m.unpack(file.Path, result.Path, provider.Hashes, ZipHash(file.SHA256)); err != nil {
+		return Result{}, err
+	}
+
+	return result, nil
+}
+
+// resolve and cache the providers.v1 service URL of host.
+func (m mirror) providersURL(ctx context.Context, host string) (string, error) {
+	if baseURL, ok := m.baseURLs[host]; ok {
+		return baseURL, nil
+	}
+
+	discoveryURL := "https://" + host + discoveryPath
+	var services map[string]any
+	if err := m.getJSON(ctx, discoveryURL, &services); err != nil {
+		return "", err
+	}
+
+	service, _ := services[providersKey].(string)
+	if service == "" {
+		return "", fmt.Errorf("%w : %s does not provide %s service", ErrRegistry, host, providersKey)
+	}
+
+	baseURL, err := resolveURL(discoveryURL, service)
+	if err != nil {
+		return "", err
+	}
+	m.baseURLs[host] = baseURL
+
+	return baseURL, nil
+}
+
+// return highest version matching constraints and available for target platform.
+func (m mirror) selectVersion(ctx context.Context, baseURL string, provider Provider) (string, error) {
+	constraints, err := version.NewConstraint(strings.Join(provider.Constraints, ","))
+	if len(provider.Constraints) == 0 {
+		constraints, err = version.Constraints{}, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	versionsURL, err := url.JoinPath(baseURL, provider.Address.Namespace, provider.Address.Type, "versions")
+	if err != nil {
+		return "", err
+	}
+
+	var info versionsInfo
+	if err = m.getJSON(ctx, versionsURL, &info); err != nil {
+		return "", err
+	}
+
+	var selected *version.Version
+	for _, versionInfo := range info.Versions {
+		current, err := version.NewVersion(versionInfo.Version)
+		if err != nil || (len(constraints) == 0 && current.Prerelease() != "") || !constraints.Check(current) || (selected != nil && !current.GreaterThan(selected)) {
+			continue
+		}
+
+		for _, platform := range versionInfo.Platforms {
+			if platform.OS == m.goos && platform.Arch == m.arch {
+				selected = current
+
+				break
+			}
+		}
+	}
+
+	if selected == nil {
+		return "", fmt.Errorf("%w : %s for %s_%s", ErrNoVersion, strings.Join(provider.Constraints, ","), m.goos, m.arch)
+	}
+
+	return selected.String(), nil
+}
+
+func (m mirror) getJSON(ctx context.Context, url string, target any) error {
+	data, err := download.Bytes(ctx, url, download.NoDisplay, checkStatus)
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("%w : invalid response from %s : %w", ErrRegistry, url, err)
+	}
+
+	return nil
+}
+
+// unzip in a temporary directory then move it to targetPath once lock file hashes are checked, return the "h1:" hash.
+func (m mirror) unpack(archivePath string, targetPath string, lockHashes []string, zipHash string) (string, error) {
+	parentPath := filepath.Dir(targetPath)
+	if err := os.MkdirAll(parentPath, fileperm.RWE); err != nil {
+		return "", err
+	}
+
+	tmpPath, err := os.MkdirTemp(parentPath, ".tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpPath)
+
+	if err = zip.UnzipToDir(archivePath, tmpPath, func(string) bool { return true }); err != nil {
+		return "", err
+	}
+
+	hash, err := HashDir(tmpPath)
+	if err != nil {
+		return "", err
+	}
+
+	if len(lockHashes) != 0 && !slices.Contains(lockHashes, hash) && !slices.Contains(lockHashes, zipHash) {
+		return "", ErrLockHash
+	}
+
+	if err = os.RemoveAll(targetPath); err != nil {
+		return "", err
+	}
+
+	return hash, os.Rename(tmpPath, targetPath)
+}
+
+func checkStatus(response *http.Response) error {
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("%w : %s returned %s", ErrRegistry, response.Request.URL, response.Status)
+	}
+
+	return nil
+}
+
+func resolveURL(baseURL string, ref string) (string, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := base.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+
+	return resolved.String(), nil
+}
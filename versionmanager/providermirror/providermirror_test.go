@@ -0,0 +1,233 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package providermirror_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/versionmanager/providermirror"
+	iacparser "github.com/tofuutils/tenv/v4/versionmanager/semantic/parser/iac"
+)
+
+const (
+	binaryContent = "#!/bin/sh\necho provider"
+	binaryName    = "terraform-provider-null_v3.2.0"
+	versionsJSON  = `{"versions":[{"version":"3.1.0","platforms":[{"os":"linux","arch":"amd64"}]},
+{"version":"3.2.0","platforms":[{"os":"linux","arch":"amd64"}]},
+{"version":"3.3.0","platforms":[{"os":"darwin","arch":"arm64"}]},
+{"version":"4.0.0","platforms":[{"os":"linux","arch":"amd64"}]}]}`
+)
+
+func TestParseAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]providermirror.Address{
+		"null":                                {Host: providermirror.DefaultHost, Namespace: "hashicorp", Type: "null"},
+		"Integrations/GitHub":                 {Host: providermirror.DefaultHost, Namespace: "integrations", Type: "github"},
+		"registry.opentofu.org/hashicorp/aws": {Host: "registry.opentofu.org", Namespace: "hashicorp", Type: "aws"},
+	}
+
+	for source, expected := range tests {
+		address, err := providermirror.ParseAddress(source, providermirror.DefaultHost)
+		if err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+
+		if address != expected {
+			t.Error("Unmatching results, expected", expected, "get", address)
+		}
+	}
+
+	if _, err := providermirror.ParseAddress("a/b/c/d", providermirror.DefaultHost); !errors.Is(err, providermirror.ErrAddress) {
+		t.Error("Should fail on too many parts, get :", err)
+	}
+}
+
+func TestMirror(t *testing.T) {
+	t.Parallel()
+
+	dataArchive := buildArchive(t)
+	sum := sha256.Sum256(dataArchive)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/.well-known/terraform.json":
+			writer.Write([]byte(`{"providers.v1":"/v1/providers/"}`)) //nolint
+		case "/v1/providers/hashicorp/null/versions":
+			writer.Write([]byte(versionsJSON)) //nolint
+		case "/v1/providers/hashicorp/null/3.2.0/download/linux/amd64":
+			writer.Write([]byte(`{"download_url":"/files/null.zip","filename":"null.zip","shasum":"` + hex.EncodeToString(sum[:]) + `"}`)) //nolint
+		case "/files/null.zip":
+			writer.Write(dataArchive) //nolint
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	serverURL, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	host := serverURL.Host
+
+	tests := []struct {
+		name    string
+		hashes  []string
+		version string
+		wantErr error
+	}{
+		{name: "Constraint"},
+		{name: "Locked", hashes: []string{"zh:" + hex.EncodeToString(sum[:])}, version: "3.2.0"},
+		{name: "WrongLockHash", hashes: []string{"zh:0000"}, version: "3.2.0", wantErr: providermirror.ErrLockHash},
+		{name: "UnknownVersion", version: "3.3.0", wantErr: providermirror.ErrRegistry},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := &config.Config{Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv, HTTPClient: server.Client()}
+			requirements := []iacparser.ProviderRequirement{{Name: "null", Source: "hashicorp/null", Version: "~> 3.1"}}
+			var locked []providermirror.Provider
+			if test.version != "" {
+				address := providermirror.Address{Host: host, Namespace: "hashicorp", Type: "null"}
+				locked = append(locked, providermirror.Provider{Address: address, Hashes: test.hashes, Version: test.version})
+			}
+
+			providers, err := providermirror.Collect(requirements, locked, host)
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if len(providers) != 1 {
+				t.Fatal("Unmatching results, get :", providers)
+			}
+
+			dirPath := t.TempDir()
+			results, err := providermirror.Mirror(t.Context(), conf, providers, dirPath, "linux", "amd64")
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatal("Unexpected error, expected", test.wantErr, "get :", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			expectedPath := filepath.Join(dirPath, host, "hashicorp", "null", "3.2.0", "linux_amd64")
+			if len(results) != 1 || results[0].Version != "3.2.0" || results[0].Path != expectedPath || results[0].Skipped {
+				t.Fatal("Unmatching results, get :", results)
+			}
+
+			data, err := os.ReadFile(filepath.Join(expectedPath, binaryName))
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if string(data) != binaryContent {
+				t.Error("Unmatching binary content, get :", string(data))
+			}
+
+			// a second call find the provider already unpacked
+			providers[0].Hashes = append(providers[0].Hashes, results[0].Hash)
+			results, err = providermirror.Mirror(t.Context(), conf, providers, dirPath, "linux", "amd64")
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if len(results) != 1 || !results[0].Skipped {
+				t.Error("Unmatching results, get :", results)
+			}
+		})
+	}
+}
+
+func TestReadLockFile(t *testing.T) {
+	t.Parallel()
+
+	filePath := filepath.Join(t.TempDir(), providermirror.LockFileName)
+	lockContent := `provider "registry.terraform.io/hashicorp/null" {
+  version     = "3.2.0"
+  constraints = "~> 3.1"
+  hashes = [
+    "h1:abc=",
+    "zh:0123",
+  ]
+}
+`
+	if err := os.WriteFile(filePath, []byte(lockContent), 0o600); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	providers, err := providermirror.ReadLockFile(filePath)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if len(providers) != 1 {
+		t.Fatal("Unmatching results, get :", providers)
+	}
+
+	provider := providers[0]
+	if provider.Address.String() != "registry.terraform.io/hashicorp/null" || provider.Version != "3.2.0" ||
+		len(provider.Constraints) != 1 || provider.Constraints[0] != "~> 3.1" || len(provider.Hashes) != 2 || provider.Hashes[1] != "zh:0123" {
+		t.Error("Unmatching results, get :", provider)
+	}
+
+	if providers, err = providermirror.ReadLockFile(filepath.Join(t.TempDir(), "missing.hcl")); err != nil || providers != nil {
+		t.Error("Unmatching results, get :", providers, err)
+	}
+}
+
+func buildArchive(t *testing.T) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	header := &zip.FileHeader{Name: binaryName, Method: zip.Deflate}
+	header.SetMode(0o755)
+
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+	writer.Write([]byte(binaryContent)) //nolint
+
+	if err = zipWriter.Close(); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	return buffer.Bytes()
+}
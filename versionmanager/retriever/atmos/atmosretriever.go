@@ -20,6 +20,7 @@ package atmosretriever
 
 import (
 	"context"
+	"encoding/hex"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -35,8 +36,12 @@ import (
 	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
 	"github.com/tofuutils/tenv/v4/pkg/download"
 	"github.com/tofuutils/tenv/v4/pkg/github"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
 	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
+	"github.com/tofuutils/tenv/v4/versionmanager/projectlock"
 	htmlretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/html"
+	"github.com/tofuutils/tenv/v4/versionmanager/retriever/signature"
 )
 
 const (
@@ -46,6 +51,8 @@ const (
 	rwePerm = 0o755
 )
 
+const repository = cloudposseName + "/" + cmdconst.AtmosName
+
 type AtmosRetriever struct {
 	conf *config.Config
 }
@@ -54,72 +61,108 @@ func Make(conf *config.Config) AtmosRetriever {
 	return AtmosRetriever{conf: conf}
 }
 
-func (r AtmosRetriever) Install(ctx context.Context, versionStr string, targetPath string) error {
+func (r AtmosRetriever) Checksum(ctx context.Context, versionStr string, goos string, arch string) (string, error) {
 	err := r.conf.InitRemoteConf()
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	tag := versionStr
-	// assume that atmos tags start with a 'v'
-	// and version in asset name does not
-	if tag[0] == 'v' {
-		versionStr = versionStr[1:]
-	} else {
-		tag = "v" + versionStr
+	if ctx, err = r.conf.DownloadContext(ctx, r.conf.Atmos); err != nil {
+		return "", err
 	}
 
-	var assetURLs []string
-	fileName, shaFileName := buildAssetNames(versionStr, r.conf.Arch)
-	if r.conf.Displayer.IsDebug() {
-		r.conf.Displayer.Log(hclog.Debug, apimsg.MsgSearch, apimsg.AssetsName, []string{fileName, shaFileName})
+	versionStr, tag := splitVersionTag(versionStr)
+	fileName, assetURLs, err := r.assetURLs(ctx, versionStr, tag, goos, arch)
+	if err != nil {
+		return "", err
 	}
 
-	switch r.conf.Atmos.GetInstallMode() {
-	case config.InstallModeDirect:
-		baseAssetURL, err2 := url.JoinPath(r.conf.Atmos.GetRemoteURL(), cloudposseName, cmdconst.AtmosName, github.Releases, github.Download, tag)
-		if err2 != nil {
-			return err2
-		}
+	requestOptions := config.GetBasicAuthOption(r.conf.Getenv, envname.AtmosRemoteUser, envname.AtmosRemotePass)
+	dataSums, err := r.conf.DownloadCache().Bytes(ctx, assetURLs[1], r.conf.Displayer.Display, download.NoCheck, requestOptions...)
+	if err != nil {
+		return "", err
+	}
 
-		assetURLs, err = htmlretriever.BuildAssetURLs(baseAssetURL, fileName, shaFileName)
-	case config.ModeAPI:
-		assetURLs, err = github.AssetDownloadURL(ctx, tag, []string{fileName, shaFileName}, r.conf.Atmos.GetRemoteURL(), r.conf.GithubToken, r.conf.Displayer.Display)
-	default:
-		return config.ErrInstallMode
+	if _, _, err = signature.CheckSums(ctx, r.conf, r.conf.Atmos, dataSums, assetURLs[2], r.signer(tag), requestOptions); err != nil {
+		return "", err
 	}
+
+	dataSum, err := sha256check.Extract(dataSums, fileName)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	assetURLs, err = download.ApplyURLTransformer(r.conf.Atmos.GetRewriteRule(), assetURLs...)
+	return hex.EncodeToString(dataSum), nil
+}
+
+func (r AtmosRetriever) Install(ctx context.Context, versionStr string, targetPath string) error {
+	_, err := r.InstallWithManifest(ctx, versionStr, targetPath)
+
+	return err
+}
+
+func (r AtmosRetriever) InstallWithManifest(ctx context.Context, versionStr string, targetPath string) (manifest.Manifest, error) {
+	err := r.conf.InitRemoteConf()
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	if ctx, err = r.conf.DownloadContext(ctx, r.conf.Atmos); err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	versionStr, tag := splitVersionTag(versionStr)
+	fileName, assetURLs, err := r.assetURLs(ctx, versionStr, tag, runtime.GOOS, r.conf.Arch)
 	if err != nil {
-		return err
+		return manifest.Manifest{}, err
 	}
 
 	requestOptions := config.GetBasicAuthOption(r.conf.Getenv, envname.AtmosRemoteUser, envname.AtmosRemotePass)
-	data, err := download.Bytes(ctx, assetURLs[0], r.conf.Displayer.Display, download.NoCheck, requestOptions...)
+	file, err := r.conf.DownloadCache().File(ctx, assetURLs[0], r.conf.Displayer.Display, loghelper.ProgressFunc(r.conf.Displayer), download.NoCheck, requestOptions...)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+	defer file.Release()
+
+	validation, _, err := r.conf.GetValidation(r.conf.Atmos)
 	if err != nil {
-		return err
+		return manifest.Manifest{}, err
 	}
 
-	if r.conf.Validation != config.NoValidation {
-		dataSums, err := download.Bytes(ctx, assetURLs[1], r.conf.Displayer.Display, download.NoCheck, requestOptions...)
+	installManifest := manifest.Manifest{ArchiveSHA256: hex.EncodeToString(file.SHA256), SourceURL: assetURLs[0], Validation: config.NoValidation}
+	if validation != config.NoValidation {
+		dataSums, err := r.conf.DownloadCache().Bytes(ctx, assetURLs[1], r.conf.Displayer.Display, download.NoCheck, requestOptions...)
 		if err != nil {
-			return err
+			return manifest.Manifest{}, err
 		}
 
-		if err = sha256check.Check(data, dataSums, fileName); err != nil {
-			return err
+		if err = sha256check.CheckSum(file.SHA256, dataSums, fileName); err != nil {
+			return manifest.Manifest{}, err
 		}
+
+		installManifest.Validation, installManifest.SignatureIdentity, err = signature.CheckSums(ctx, r.conf, r.conf.Atmos, dataSums, assetURLs[2], r.signer(tag), requestOptions)
+		if err != nil {
+			return manifest.Manifest{}, err
+		}
+
+		if installManifest.Validation == config.AttestationValidation {
+			installManifest.Validation, installManifest.SignatureIdentity, err = signature.CheckAttestation(ctx, r.conf, r.conf.Atmos, repository, r.releaseWorkflow(), file.SHA256)
+			if err != nil {
+				return manifest.Manifest{}, err
+			}
+		}
+	}
+
+	if err = projectlock.Check(r.conf, cmdconst.AtmosName, versionStr, runtime.GOOS, r.conf.Arch, file.SHA256); err != nil {
+		return manifest.Manifest{}, err
 	}
 
 	err = os.MkdirAll(targetPath, rwePerm)
 	if err != nil {
-		return err
+		return manifest.Manifest{}, err
 	}
 
-	return os.WriteFile(filepath.Join(targetPath, winbin.GetBinaryName(cmdconst.AtmosName)), data, rwePerm)
+	return installManifest, file.CopyTo(filepath.Join(targetPath, winbin.GetBinaryName(cmdconst.AtmosName)), rwePerm)
 }
 
 func (r AtmosRetriever) ListVersions(ctx context.Context) ([]string, error) {
@@ -128,6 +171,10 @@ func (r AtmosRetriever) ListVersions(ctx context.Context) ([]string, error) {
 		return nil, err
 	}
 
+	if ctx, err = r.conf.DownloadContext(ctx, r.conf.Atmos); err != nil {
+		return nil, err
+	}
+
 	requestOptions := config.GetBasicAuthOption(r.conf.Getenv, envname.AtmosRemoteUser, envname.AtmosRemotePass)
 
 	listURL := r.conf.Atmos.GetListURL()
@@ -144,23 +191,78 @@ func (r AtmosRetriever) ListVersions(ctx context.Context) ([]string, error) {
 	case config.ModeAPI:
 		r.conf.Displayer.Display(apimsg.MsgFetchAllReleases + listURL)
 
-		return github.ListReleases(ctx, listURL, r.conf.GithubToken)
+		return github.ListReleases(ctx, listURL, r.conf.GithubToken, r.conf.GithubCache())
 	default:
 		return nil, config.ErrListMode
 	}
 }
 
-func buildAssetNames(version string, arch string) (string, string) {
+func (r AtmosRetriever) assetURLs(ctx context.Context, versionStr string, tag string, goos string, arch string) (string, []string, error) {
+	var err error
+	var assetURLs []string
+	fileName, shaFileName := buildAssetNames(versionStr, goos, arch)
+	if r.conf.Displayer.IsDebug() {
+		r.conf.Displayer.Log(hclog.Debug, apimsg.MsgSearch, apimsg.AssetsName, []string{fileName, shaFileName})
+	}
+
+	switch r.conf.Atmos.GetInstallMode() {
+	case config.InstallModeDirect:
+		baseAssetURL, err2 := url.JoinPath(r.conf.Atmos.GetRemoteURL(), cloudposseName, cmdconst.AtmosName, github.Releases, github.Download, tag)
+		if err2 != nil {
+			return "", nil, err2
+		}
+
+		assetURLs, err = htmlretriever.BuildAssetURLs(baseAssetURL, fileName, shaFileName, shaFileName+signature.BundleSuffix)
+	case config.ModeAPI:
+		assetURLs, err = github.AssetDownloadURL(ctx, tag, []string{fileName, shaFileName}, r.conf.Atmos.GetRemoteURL(), r.conf.GithubToken, r.conf.GithubCache(), r.conf.Displayer.Display)
+		if err == nil {
+			var bundleURL string
+			if validation, _, _ := r.conf.GetValidation(r.conf.Atmos); validation == config.SignValidation {
+				bundleURL, err = signature.BundleURL(ctx, r.conf, r.conf.Atmos, tag, shaFileName)
+			}
+			assetURLs = append(assetURLs, bundleURL)
+		}
+	default:
+		return "", nil, config.ErrInstallMode
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	assetURLs, err = download.ApplyURLTransformer(r.conf.Atmos.GetRewriteRule(), assetURLs...)
+
+	return fileName, assetURLs, err
+}
+
+func buildAssetNames(version string, goos string, arch string) (string, string) {
 	var nameBuilder strings.Builder
 	nameBuilder.WriteString(baseFileName)
 	nameBuilder.WriteString(version)
 	nameBuilder.WriteByte('_')
 	sumsAssetName := nameBuilder.String() + "SHA256SUMS"
 
-	nameBuilder.WriteString(runtime.GOOS)
+	nameBuilder.WriteString(goos)
 	nameBuilder.WriteByte('_')
 	nameBuilder.WriteString(arch)
-	_, _ = winbin.WriteSuffixTo(&nameBuilder)
+	_, _ = winbin.WriteSuffixForOSTo(goos, &nameBuilder)
 
 	return nameBuilder.String(), sumsAssetName
 }
+
+// assume that atmos tags start with a 'v'
+// and version in asset name does not.
+func splitVersionTag(versionStr string) (string, string) {
+	if versionStr[0] == 'v' {
+		return versionStr[1:], versionStr
+	}
+
+	return versionStr, "v" + versionStr
+}
+
+func (r AtmosRetriever) releaseWorkflow() string {
+	return r.conf.Atmos.GetReleaseWorkflow(config.DefaultReleaseWorkflow)
+}
+
+func (r AtmosRetriever) signer(tag string) signature.Signer {
+	return signature.GithubWorkflowSigner(repository, r.releaseWorkflow(), tag)
+}
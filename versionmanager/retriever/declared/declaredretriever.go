@@ -0,0 +1,328 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package declaredretriever
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/apimsg"
+	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/fileperm"
+	"github.com/tofuutils/tenv/v4/pkg/github"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/pkg/pathfilter"
+	"github.com/tofuutils/tenv/v4/pkg/uncompress"
+	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
+	"github.com/tofuutils/tenv/v4/versionmanager/projectlock"
+	htmlretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/html"
+	"github.com/tofuutils/tenv/v4/versionmanager/retriever/signature"
+)
+
+var (
+	ErrNoBinary   = errors.New("binary not found in archive")
+	ErrNoChecksum = errors.New("no checksum asset declared")
+)
+
+// DeclaredRetriever handles a tool released on GitHub, described by a config.ToolDefinition.
+type DeclaredRetriever struct {
+	conf *config.Config
+	def  *config.ToolDefinition
+}
+
+func Make(conf *config.Config, def *config.ToolDefinition) DeclaredRetriever {
+	return DeclaredRetriever{conf: conf, def: def}
+}
+
+func (r DeclaredRetriever) Checksum(ctx context.Context, versionStr string, goos string, arch string) (string, error) {
+	if r.def.Checksum == "" {
+		return "", ErrNoChecksum
+	}
+
+	err := r.conf.InitRemoteConf()
+	if err != nil {
+		return "", err
+	}
+
+	if ctx, err = r.conf.DownloadContext(ctx, r.def.Remote); err != nil {
+		return "", err
+	}
+
+	names, err := r.def.AssetNames(versionStr, goos, arch)
+	if err != nil {
+		return "", err
+	}
+
+	assetURLs, err := r.assetURLs(ctx, names)
+	if err != nil {
+		return "", err
+	}
+
+	requestOptions := r.requestOptions()
+	dataSums, err := r.conf.DownloadCache().Bytes(ctx, assetURLs[1], r.conf.Displayer.Display, download.NoCheck, requestOptions...)
+	if err != nil {
+		return "", err
+	}
+
+	if _, _, err = signature.CheckSums(ctx, r.conf, r.def.Remote, dataSums, assetURLs[2], r.signer(names.Tag), requestOptions); err != nil {
+		return "", err
+	}
+
+	dataSum, err := sha256check.Extract(dataSums, names.Asset)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(dataSum), nil
+}
+
+func (r DeclaredRetriever) Install(ctx context.Context, versionStr string, targetPath string) error {
+	_, err := r.InstallWithManifest(ctx, versionStr, targetPath)
+
+	return err
+}
+
+func (r DeclaredRetriever) InstallWithManifest(ctx context.Context, versionStr string, targetPath string) (manifest.Manifest, error) {
+	err := r.conf.InitRemoteConf()
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	if ctx, err = r.conf.DownloadContext(ctx, r.def.Remote); err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	names, err := r.def.AssetNames(versionStr, runtime.GOOS, r.conf.Arch)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	assetURLs, err := r.assetURLs(ctx, names)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	requestOptions := r.requestOptions()
+	file, err := r.conf.DownloadCache().File(ctx, assetURLs[0], r.conf.Displayer.Display, loghelper.ProgressFunc(r.conf.Displayer), download.NoCheck, requestOptions...)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+	defer file.Release()
+
+	installManifest, err := r.check(ctx, names, assetURLs, file.SHA256, requestOptions)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	if err = projectlock.Check(r.conf, r.def.Name, names.Version, runtime.GOOS, r.conf.Arch, file.SHA256); err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	binaryPath := filepath.Join(targetPath, winbin.GetBinaryName(r.def.Name))
+	if names.Archive == config.ArchiveBinary {
+		if err = os.MkdirAll(targetPath, fileperm.RWE); err != nil {
+			return manifest.Manifest{}, err
+		}
+
+		return installManifest, file.CopyTo(binaryPath, fileperm.RWE)
+	}
+
+	// archive kind is detected from file name suffix
+	if err = uncompress.ToDir(file.Path, "."+names.Archive, targetPath, pathfilter.NameEqual(names.Binary)); err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	return installManifest, moveBinary(targetPath, names.Binary, binaryPath)
+}
+
+func (r DeclaredRetriever) ListVersions(ctx context.Context) ([]string, error) {
+	err := r.conf.InitRemoteConf()
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx, err = r.conf.DownloadContext(ctx, r.def.Remote); err != nil {
+		return nil, err
+	}
+
+	listURL := r.def.Remote.GetListURL()
+	switch r.def.Remote.GetListMode() {
+	case config.ListModeHTML:
+		baseURL, err := url.JoinPath(listURL, r.def.Repository, github.Releases, github.Download)
+		if err != nil {
+			return nil, err
+		}
+
+		r.conf.Displayer.Display(apimsg.MsgFetchAllReleases + baseURL)
+
+		return htmlretriever.ListReleases(ctx, baseURL, r.def.Remote.Data, r.requestOptions())
+	case config.ModeAPI:
+		r.conf.Displayer.Display(apimsg.MsgFetchAllReleases + listURL)
+
+		return github.ListReleases(ctx, listURL, r.conf.GithubToken, r.conf.GithubCache())
+	default:
+		return nil, config.ErrListMode
+	}
+}
+
+// return URLs of archive, checksums file (when declared) and its Sigstore bundle (empty when not found in API mode).
+func (r DeclaredRetriever) assetURLs(ctx context.Context, names config.ToolAssetNames) ([]string, error) {
+	searchedNames := []string{names.Asset}
+	if names.Checksum != "" {
+		searchedNames = append(searchedNames, names.Checksum)
+	}
+
+	if r.conf.Displayer.IsDebug() {
+		r.conf.Displayer.Log(hclog.Debug, apimsg.MsgSearch, apimsg.AssetsName, searchedNames)
+	}
+
+	var err error
+	var assetURLs []string
+	switch r.def.Remote.GetInstallMode() {
+	case config.InstallModeDirect:
+		baseAssetURL, err2 := url.JoinPath(r.def.Remote.GetRemoteURL(), r.def.Repository, github.Releases, github.Download, names.Tag)
+		if err2 != nil {
+			return nil, err2
+		}
+
+		if names.Checksum != "" {
+			searchedNames = append(searchedNames, names.Checksum+signature.BundleSuffix)
+		}
+
+		assetURLs, err = htmlretriever.BuildAssetURLs(baseAssetURL, searchedNames...)
+	case config.ModeAPI:
+		assetURLs, err = github.AssetDownloadURL(ctx, names.Tag, searchedNames, r.def.Remote.GetRemoteURL(), r.conf.GithubToken, r.conf.GithubCache(), r.conf.Displayer.Display)
+		if err == nil && names.Checksum != "" {
+			var bundleURL string
+			if validation, _, _ := r.conf.GetValidation(r.def.Remote); validation == config.SignValidation {
+				bundleURL, err = signature.BundleURL(ctx, r.conf, r.def.Remote, names.Tag, names.Checksum)
+			}
+			assetURLs = append(assetURLs, bundleURL)
+		}
+	default:
+		return nil, config.ErrInstallMode
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return download.ApplyURLTransformer(r.def.Remote.GetRewriteRule(), assetURLs...)
+}
+
+func (r DeclaredRetriever) check(ctx context.Context, names config.ToolAssetNames, assetURLs []string, sum []byte, requestOptions []download.RequestOption) (manifest.Manifest, error) {
+	installManifest := manifest.Manifest{ArchiveSHA256: hex.EncodeToString(sum), SourceURL: assetURLs[0], Validation: config.NoValidation}
+
+	validation, _, err := r.conf.GetValidation(r.def.Remote)
+	if err != nil || validation == config.NoValidation {
+		return installManifest, err
+	}
+
+	if names.Checksum == "" {
+		if err = r.conf.CheckValidation(r.def.Remote, config.NoValidation); err != nil {
+			return manifest.Manifest{}, err
+		}
+
+		r.conf.Displayer.Display(loghelper.Concat("Warning, ", ErrNoChecksum.Error(), " for ", r.def.Name, ", nothing is checked"))
+
+		return installManifest, nil
+	}
+
+	dataSums, err := r.conf.DownloadCache().Bytes(ctx, assetURLs[1], r.conf.Displayer.Display, download.NoCheck, requestOptions...)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	if err = sha256check.CheckSum(sum, dataSums, names.Asset); err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	installManifest.Validation, installManifest.SignatureIdentity, err = signature.CheckSums(ctx, r.conf, r.def.Remote, dataSums, assetURLs[2], r.signer(names.Tag), requestOptions)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	if installManifest.Validation == config.AttestationValidation {
+		installManifest.Validation, installManifest.SignatureIdentity, err = signature.CheckAttestation(ctx, r.conf, r.def.Remote, r.def.Repository, r.def.ReleaseWorkflow(), sum)
+	}
+
+	return installManifest, err
+}
+
+func (r DeclaredRetriever) signer(tag string) signature.Signer {
+	return signature.GithubWorkflowSigner(r.def.Repository, r.def.ReleaseWorkflow(), tag)
+}
+
+func (r DeclaredRetriever) requestOptions() []download.RequestOption {
+	envNames := r.def.EnvNames()
+
+	return config.GetBasicAuthOption(r.conf.Getenv, envNames.RemoteUser, envNames.RemotePass)
+}
+
+// search the extracted binary (archive paths are kept) and move it to binaryPath.
+func moveBinary(dirPath string, binaryName string, binaryPath string) error {
+	nameFilter := pathfilter.NameEqual(binaryName)
+	foundPath := ""
+	err := filepath.WalkDir(dirPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || !nameFilter(path) {
+			return err
+		}
+
+		foundPath = path
+
+		return fs.SkipAll
+	})
+	if err != nil {
+		return err
+	}
+
+	switch foundPath {
+	case "":
+		return ErrNoBinary
+	case binaryPath:
+		return nil
+	}
+
+	if err = os.Rename(foundPath, binaryPath); err != nil {
+		return err
+	}
+
+	relPath, err := filepath.Rel(dirPath, foundPath)
+	if err != nil {
+		return err
+	}
+
+	// remove the now useless extracted directory
+	if topDir, _, inDir := strings.Cut(filepath.ToSlash(relPath), "/"); inDir {
+		return os.RemoveAll(filepath.Join(dirPath, topDir))
+	}
+
+	return nil
+}
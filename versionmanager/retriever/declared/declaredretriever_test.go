@@ -0,0 +1,145 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package declaredretriever_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	declaredretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/declared"
+	"github.com/tofuutils/tenv/v4/versionmanager/retriever/retrievertest"
+)
+
+const releasePath = "/terraform-linters/tflint/releases/download/v0.50.0/"
+
+func TestInstall(t *testing.T) {
+	t.Parallel()
+
+	assetName := "tflint_" + runtime.GOOS + "_amd64.tar.gz"
+	dataArchive := retrievertest.Archive(t, retrievertest.TarGzFormat, "README.md", "tflint_"+runtime.GOOS+"/"+winbin.GetBinaryName("tflint"))
+	sum := sha256.Sum256(dataArchive)
+	wrongSum := sha256.Sum256([]byte("other"))
+
+	server := retrievertest.NewServer(t, map[string][]byte{
+		releasePath + assetName:       dataArchive,
+		releasePath + "checksums.txt": []byte(hex.EncodeToString(sum[:]) + "  " + assetName + "\n"),
+		releasePath + "wrong.txt":     []byte(hex.EncodeToString(wrongSum[:]) + "  " + assetName + "\n"),
+	})
+
+	tests := []struct {
+		name     string
+		checksum string
+		strict   bool
+		wantErr  error
+		wantMode config.ValidationMode
+	}{
+		{name: "Checksum", checksum: "checksums.txt", wantMode: config.ShaValidation},
+		{name: "WrongChecksum", checksum: "wrong.txt", wantErr: sha256check.ErrCheck},
+		{name: "NoChecksum", wantMode: config.NoValidation},
+		{name: "NoChecksumStrict", strict: true, wantErr: config.ErrValidationDowngrade},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			toolsConf := "tflint:\n  repository: terraform-linters/tflint\n  asset: \"tflint_{{.OS}}_{{.Arch}}.tar.gz\"\n"
+			if test.checksum != "" {
+				toolsConf += "  checksum: " + test.checksum + "\n"
+			}
+
+			conf := initConf(t, toolsConf, server.URL)
+			conf.StrictValidation = test.strict
+			conf.Validation = config.ShaValidation
+
+			targetPath := filepath.Join(t.TempDir(), "0.50.0")
+			installManifest, err := declaredretriever.Make(conf, conf.Tools[0]).InstallWithManifest(t.Context(), "0.50.0", targetPath)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatal("Unexpected error, expected", test.wantErr, "get :", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if installManifest.Validation != test.wantMode || installManifest.ArchiveSHA256 != hex.EncodeToString(sum[:]) {
+				t.Error("Unmatching manifest, get :", installManifest)
+			}
+
+			entries, err := os.ReadDir(targetPath)
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if len(entries) != 1 || entries[0].Name() != winbin.GetBinaryName("tflint") {
+				t.Fatal("Unmatching installed files, get :", entries)
+			}
+
+			data, err := os.ReadFile(filepath.Join(targetPath, entries[0].Name()))
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if string(data) != retrievertest.BinaryContent {
+				t.Error("Unmatching binary content, get :", string(data))
+			}
+		})
+	}
+}
+
+func initConf(t *testing.T, toolsConf string, remoteURL string) *config.Config {
+	t.Helper()
+
+	rootPath := t.TempDir()
+	toolsConfPath := filepath.Join(rootPath, "tools.yaml")
+	if err := os.WriteFile(toolsConfPath, []byte(toolsConf), 0o600); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	getenv := func(key string) string {
+		if key == "TFLINT_REMOTE" {
+			return remoteURL
+		}
+
+		return ""
+	}
+
+	conf := &config.Config{
+		Arch: "amd64", Displayer: loghelper.InertDisplayer, Getenv: getenv, GithubCacheTTL: -1,
+		RootPath: rootPath, ToolsConfPath: toolsConfPath, WorkPath: rootPath,
+	}
+	if err := conf.InitToolDefs(nil); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	return conf
+}
@@ -0,0 +1,290 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package githubreleaseretriever
+
+import (
+	"context"
+	"encoding/hex"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/apimsg"
+	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/github"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/pkg/pathfilter"
+	"github.com/tofuutils/tenv/v4/pkg/uncompress"
+	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
+	"github.com/tofuutils/tenv/v4/versionmanager/projectlock"
+	htmlretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/html"
+	"github.com/tofuutils/tenv/v4/versionmanager/retriever/signature"
+)
+
+const (
+	certSuffix = ".pem"
+	sigSuffix  = ".keyless.sig"
+)
+
+// AssetNames of a release for one platform.
+type AssetNames struct {
+	Archive string
+	Binary  string // executable in archive, renamed after the tool when different
+	Sums    string
+}
+
+// Tool describes a tool released on GitHub with a checksums file,
+// signed with a Sigstore bundle or with a cosign keyless signature and certificate.
+type Tool struct {
+	AssetNames func(tag string, goos string, arch string) AssetNames
+	Keyless    bool
+	Name       string
+	RemotePass string // environment variable names for basic auth
+	RemoteUser string
+	Repository string // owner/name
+}
+
+type GithubReleaseRetriever struct {
+	conf   *config.Config
+	remote *config.RemoteConfig // filled by conf.InitRemoteConf
+	tool   Tool
+}
+
+func Make(conf *config.Config, remote *config.RemoteConfig, tool Tool) GithubReleaseRetriever {
+	return GithubReleaseRetriever{conf: conf, remote: remote, tool: tool}
+}
+
+func (r GithubReleaseRetriever) Checksum(ctx context.Context, versionStr string, goos string, arch string) (string, error) {
+	err := r.conf.InitRemoteConf()
+	if err != nil {
+		return "", err
+	}
+
+	if ctx, err = r.conf.DownloadContext(ctx, *r.remote); err != nil {
+		return "", err
+	}
+
+	_, tag := splitVersionTag(versionStr)
+	names, assetURLs, err := r.assetURLs(ctx, tag, goos, arch)
+	if err != nil {
+		return "", err
+	}
+
+	requestOptions := config.GetBasicAuthOption(r.conf.Getenv, r.tool.RemoteUser, r.tool.RemotePass)
+	dataSums, err := r.conf.DownloadCache().Bytes(ctx, assetURLs[1], r.conf.Displayer.Display, download.NoCheck, requestOptions...)
+	if err != nil {
+		return "", err
+	}
+
+	if _, _, err = r.checkSignature(ctx, tag, dataSums, assetURLs, requestOptions); err != nil {
+		return "", err
+	}
+
+	dataSum, err := sha256check.Extract(dataSums, names.Archive)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(dataSum), nil
+}
+
+func (r GithubReleaseRetriever) Install(ctx context.Context, versionStr string, targetPath string) error {
+	_, err := r.InstallWithManifest(ctx, versionStr, targetPath)
+
+	return err
+}
+
+func (r GithubReleaseRetriever) InstallWithManifest(ctx context.Context, versionStr string, targetPath string) (manifest.Manifest, error) {
+	err := r.conf.InitRemoteConf()
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	if ctx, err = r.conf.DownloadContext(ctx, *r.remote); err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	versionStr, tag := splitVersionTag(versionStr)
+	names, assetURLs, err := r.assetURLs(ctx, tag, runtime.GOOS, r.conf.Arch)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	requestOptions := config.GetBasicAuthOption(r.conf.Getenv, r.tool.RemoteUser, r.tool.RemotePass)
+	file, err := r.conf.DownloadCache().File(ctx, assetURLs[0], r.conf.Displayer.Display, loghelper.ProgressFunc(r.conf.Displayer), download.NoCheck, requestOptions...)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+	defer file.Release()
+
+	validation, _, err := r.conf.GetValidation(*r.remote)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	installManifest := manifest.Manifest{ArchiveSHA256: hex.EncodeToString(file.SHA256), SourceURL: assetURLs[0], Validation: config.NoValidation}
+	if validation != config.NoValidation {
+		dataSums, err := r.conf.DownloadCache().Bytes(ctx, assetURLs[1], r.conf.Displayer.Display, download.NoCheck, requestOptions...)
+		if err != nil {
+			return manifest.Manifest{}, err
+		}
+
+		if err = sha256check.CheckSum(file.SHA256, dataSums, names.Archive); err != nil {
+			return manifest.Manifest{}, err
+		}
+
+		installManifest.Validation, installManifest.SignatureIdentity, err = r.checkSignature(ctx, tag, dataSums, assetURLs, requestOptions)
+		if err != nil {
+			return manifest.Manifest{}, err
+		}
+
+		if installManifest.Validation == config.AttestationValidation {
+			installManifest.Validation, installManifest.SignatureIdentity, err = signature.CheckAttestation(ctx, r.conf, *r.remote, r.tool.Repository, r.releaseWorkflow(), file.SHA256)
+			if err != nil {
+				return manifest.Manifest{}, err
+			}
+		}
+	}
+
+	if err = projectlock.Check(r.conf, r.tool.Name, versionStr, runtime.GOOS, r.conf.Arch, file.SHA256); err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	if err = uncompress.ToDir(file.Path, names.Archive, targetPath, pathfilter.NameEqual(names.Binary)); err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	if binaryName := winbin.GetBinaryName(r.tool.Name); names.Binary != binaryName {
+		err = os.Rename(filepath.Join(targetPath, names.Binary), filepath.Join(targetPath, binaryName))
+	}
+
+	return installManifest, err
+}
+
+func (r GithubReleaseRetriever) ListVersions(ctx context.Context) ([]string, error) {
+	err := r.conf.InitRemoteConf()
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx, err = r.conf.DownloadContext(ctx, *r.remote); err != nil {
+		return nil, err
+	}
+
+	requestOptions := config.GetBasicAuthOption(r.conf.Getenv, r.tool.RemoteUser, r.tool.RemotePass)
+
+	listURL := r.remote.GetListURL()
+	switch r.remote.GetListMode() {
+	case config.ListModeHTML:
+		baseURL, err := url.JoinPath(listURL, r.tool.Repository, github.Releases, github.Download)
+		if err != nil {
+			return nil, err
+		}
+
+		r.conf.Displayer.Display(apimsg.MsgFetchAllReleases + baseURL)
+
+		return htmlretriever.ListReleases(ctx, baseURL, r.remote.Data, requestOptions)
+	case config.ModeAPI:
+		r.conf.Displayer.Display(apimsg.MsgFetchAllReleases + listURL)
+
+		return github.ListReleases(ctx, listURL, r.conf.GithubToken, r.conf.GithubCache())
+	default:
+		return nil, config.ErrListMode
+	}
+}
+
+// return asset names and URLs of archive, checksums file and signature files
+// (bundle, or keyless signature and certificate, empty when not published).
+func (r GithubReleaseRetriever) assetURLs(ctx context.Context, tag string, goos string, arch string) (AssetNames, []string, error) {
+	var err error
+	var assetURLs []string
+	names := r.tool.AssetNames(tag, goos, arch)
+	sigFileNames := []string{names.Sums + signature.BundleSuffix}
+	if r.tool.Keyless {
+		sigFileNames = []string{names.Sums + sigSuffix, names.Sums + certSuffix}
+	}
+
+	if r.conf.Displayer.IsDebug() {
+		r.conf.Displayer.Log(hclog.Debug, apimsg.MsgSearch, apimsg.AssetsName, []string{names.Archive, names.Sums})
+	}
+
+	switch r.remote.GetInstallMode() {
+	case config.InstallModeDirect:
+		baseAssetURL, err2 := url.JoinPath(r.remote.GetRemoteURL(), r.tool.Repository, github.Releases, github.Download, tag)
+		if err2 != nil {
+			return AssetNames{}, nil, err2
+		}
+
+		assetURLs, err = htmlretriever.BuildAssetURLs(baseAssetURL, append([]string{names.Archive, names.Sums}, sigFileNames...)...)
+	case config.ModeAPI:
+		assetURLs, err = github.AssetDownloadURL(ctx, tag, []string{names.Archive, names.Sums}, r.remote.GetRemoteURL(), r.conf.GithubToken, r.conf.GithubCache(), r.conf.Displayer.Display)
+		if err == nil {
+			sigURLs := make([]string, len(sigFileNames))
+			if validation, _, _ := r.conf.GetValidation(*r.remote); validation == config.SignValidation {
+				for i, sigFileName := range sigFileNames {
+					if sigURLs[i], err = signature.OptionalAssetURL(ctx, r.conf, *r.remote, tag, sigFileName); err != nil {
+						break
+					}
+				}
+			}
+			assetURLs = append(assetURLs, sigURLs...)
+		}
+	default:
+		return AssetNames{}, nil, config.ErrInstallMode
+	}
+	if err != nil {
+		return AssetNames{}, nil, err
+	}
+
+	assetURLs, err = download.ApplyURLTransformer(r.remote.GetRewriteRule(), assetURLs...)
+
+	return names, assetURLs, err
+}
+
+func (r GithubReleaseRetriever) checkSignature(ctx context.Context, tag string, dataSums []byte, assetURLs []string, requestOptions []download.RequestOption) (config.ValidationMode, string, error) {
+	if r.tool.Keyless {
+		return signature.CheckKeyless(ctx, r.conf, *r.remote, dataSums, assetURLs[2], assetURLs[3], r.signer(tag), requestOptions)
+	}
+
+	return signature.CheckSums(ctx, r.conf, *r.remote, dataSums, assetURLs[2], r.signer(tag), requestOptions)
+}
+
+func (r GithubReleaseRetriever) releaseWorkflow() string {
+	return r.remote.GetReleaseWorkflow(config.DefaultReleaseWorkflow)
+}
+
+func (r GithubReleaseRetriever) signer(tag string) signature.Signer {
+	return signature.GithubWorkflowSigner(r.tool.Repository, r.releaseWorkflow(), tag)
+}
+
+// assume that tags start with a 'v'
+// and version in lock file does not.
+func splitVersionTag(versionStr string) (string, string) {
+	if versionStr[0] == 'v' {
+		return versionStr[1:], versionStr
+	}
+
+	return versionStr, "v" + versionStr
+}
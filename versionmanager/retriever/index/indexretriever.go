@@ -0,0 +1,116 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package indexretriever
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/apimsg"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	releaseapi "github.com/tofuutils/tenv/v4/versionmanager/retriever/terraform/api"
+)
+
+const (
+	fileURLPrefix = "file://"
+	indexJSON     = "index.json"
+)
+
+// IndexURL returns the URL of the index file describing all releases of toolName (<baseURL>/<toolName>/index.json).
+func IndexURL(baseURL string, toolName string) (string, error) {
+	return url.JoinPath(baseURL, toolName, indexJSON)
+}
+
+// RequestOptions returns options allowing file:// URLs only when mode is index and baseURL is itself a file:// URL (local mirror).
+// Otherwise file:// URLs stay refused, even when declared in a remote index file.
+func RequestOptions(mode string, baseURL string, options []download.RequestOption) []download.RequestOption {
+	if mode != config.ModeIndex || !strings.HasPrefix(baseURL, fileURLPrefix) {
+		return options
+	}
+
+	return append(slices.Clip(options), download.AllowLocalFile)
+}
+
+// ListReleases returns versions declared in index file (same format as releases.hashicorp.com index.json files).
+func ListReleases(ctx context.Context, indexURL string, options []download.RequestOption) ([]string, error) {
+	value, err := download.JSON(ctx, indexURL, download.NoDisplay, download.NoCheck, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return releaseapi.ExtractReleases(value)
+}
+
+// AssetURLs returns archive name and URLs of archive, checksums file and its signature, read from version entry of index file.
+// A relative archive URL is resolved against index URL, and checksums files against archive URL (they are expected next to it).
+func AssetURLs(ctx context.Context, indexURL string, version string, goos string, arch string, options []download.RequestOption) (string, []string, error) {
+	value, err := download.JSON(ctx, indexURL, download.NoDisplay, download.NoCheck, options...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	object, _ := value.(map[string]any)
+	versions, ok := object["versions"].(map[string]any)
+	if !ok {
+		return "", nil, apimsg.ErrReturn
+	}
+
+	versionValue, ok := versions[version]
+	if !ok {
+		return "", nil, fmt.Errorf("%w : version %s not declared in %s", apimsg.ErrAsset, version, indexURL)
+	}
+
+	fileName, downloadURL, shaFileName, shaSigFileName, err := releaseapi.ExtractAssetURLs(goos, arch, versionValue)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if downloadURL, err = resolveURL(indexURL, downloadURL); err != nil {
+		return "", nil, err
+	}
+
+	assetURLs := []string{downloadURL}
+	for _, shaName := range []string{shaFileName, shaSigFileName} {
+		shaURL, err := resolveURL(downloadURL, shaName)
+		if err != nil {
+			return "", nil, err
+		}
+		assetURLs = append(assetURLs, shaURL)
+	}
+
+	return fileName, assetURLs, nil
+}
+
+func resolveURL(baseURL string, ref string) (string, error) {
+	base, err := url.Parse(baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, err := base.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+
+	return resolved.String(), nil
+}
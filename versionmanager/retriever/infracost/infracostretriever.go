@@ -0,0 +1,66 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package infracostretriever
+
+import (
+	"strings"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/config/cmdconst"
+	"github.com/tofuutils/tenv/v4/config/envname"
+	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	githubreleaseretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/githubrelease"
+)
+
+const (
+	baseFileName = cmdconst.InfracostName + "-"
+	sumsSuffix   = ".sha256"
+	tarGzSuffix  = ".tar.gz"
+)
+
+type InfracostRetriever struct {
+	githubreleaseretriever.GithubReleaseRetriever
+}
+
+func Make(conf *config.Config) InfracostRetriever {
+	return InfracostRetriever{GithubReleaseRetriever: githubreleaseretriever.Make(conf, &conf.Infracost, githubreleaseretriever.Tool{
+		AssetNames: buildAssetNames,
+		Name:       cmdconst.InfracostName,
+		RemotePass: envname.InfracostRemotePass,
+		RemoteUser: envname.InfracostRemoteUser,
+		// infracost organization has the same name than its repository.
+		Repository: cmdconst.InfracostName + "/" + cmdconst.InfracostName,
+	})}
+}
+
+// infracost asset names do not contain version, each archive has its own checksum file
+// and the executable in archive is named after the platform.
+func buildAssetNames(_ string, goos string, arch string) githubreleaseretriever.AssetNames {
+	var nameBuilder strings.Builder
+	nameBuilder.WriteString(baseFileName)
+	nameBuilder.WriteString(goos)
+	nameBuilder.WriteByte('-')
+	nameBuilder.WriteString(arch)
+	platformName := nameBuilder.String()
+	_, _ = winbin.WriteSuffixForOSTo(goos, &nameBuilder)
+
+	archiveName := platformName + tarGzSuffix
+
+	return githubreleaseretriever.AssetNames{Archive: archiveName, Binary: nameBuilder.String(), Sums: archiveName + sumsSuffix}
+}
@@ -0,0 +1,136 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package infracostretriever_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
+	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	infracostretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/infracost"
+	"github.com/tofuutils/tenv/v4/versionmanager/retriever/retrievertest"
+	"github.com/tofuutils/tenv/v4/versionmanager/retriever/signature"
+)
+
+const releasePath = "/infracost/infracost/releases/download/v0.10.41/"
+
+// infracost publishes no shared checksums file, only a .sha256 file next to each archive (asset names do not contain version).
+func TestInstall(t *testing.T) {
+	t.Parallel()
+
+	assetName := "infracost-" + runtime.GOOS + "-amd64.tar.gz"
+	dataArchive := retrievertest.Archive(t, retrievertest.TarGzFormat, winbin.GetBinaryName("infracost-"+runtime.GOOS+"-amd64"))
+	sum := sha256.Sum256(dataArchive)
+	wrongSum := sha256.Sum256([]byte("other"))
+	sumsByVersion := map[string]string{
+		"v0.10.41": hex.EncodeToString(sum[:]) + "  " + assetName + "\n",
+		"v0.10.40": hex.EncodeToString(wrongSum[:]) + "  " + assetName + "\n",
+		"v0.10.39": hex.EncodeToString(sum[:]) + "  infracost-other-amd64.tar.gz\n",
+	}
+
+	files := map[string][]byte{}
+	for tag, sums := range sumsByVersion {
+		tagPath := "/infracost/infracost/releases/download/" + tag + "/" + assetName
+		files[tagPath] = dataArchive
+		files[tagPath+".sha256"] = []byte(sums)
+	}
+	server := retrievertest.NewServer(t, files)
+
+	tests := []struct {
+		name       string
+		missingSig config.MissingSigPolicy
+		validation config.ValidationMode
+		version    string
+		wantErr    error
+		wantMode   config.ValidationMode
+	}{
+		{name: "ShaValidation", validation: config.ShaValidation, version: "0.10.41", wantMode: config.ShaValidation},
+		{name: "TagVersion", validation: config.ShaValidation, version: "v0.10.41", wantMode: config.ShaValidation},
+		{name: "NoValidation", validation: config.NoValidation, version: "0.10.40", wantMode: config.NoValidation},
+		{name: "WrongSum", validation: config.ShaValidation, version: "0.10.40", wantErr: sha256check.ErrCheck},
+		{name: "OtherAssetSum", validation: config.ShaValidation, version: "0.10.39", wantErr: sha256check.ErrNoSum},
+		{name: "NoSignatureWarn", validation: config.SignValidation, version: "0.10.41", wantMode: config.ShaValidation},
+		{name: "NoSignatureFail", missingSig: config.FailMissingSig, validation: config.SignValidation, version: "0.10.41", wantErr: signature.ErrNoSignature},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := retrievertest.DirectConf(t, "infracost", server.URL)
+			conf.MissingSig = test.missingSig
+			conf.Validation = test.validation
+
+			targetPath := filepath.Join(conf.RootPath, "0.10.41")
+			installManifest, err := infracostretriever.Make(conf).InstallWithManifest(t.Context(), test.version, targetPath)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatal("Should fail on", test.wantErr, ", get :", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if installManifest.Validation != test.wantMode || installManifest.ArchiveSHA256 != hex.EncodeToString(sum[:]) {
+				t.Error("Unmatching manifest, get :", installManifest)
+			}
+
+			// the platform named executable is renamed
+			data, err := os.ReadFile(filepath.Join(targetPath, winbin.GetBinaryName("infracost")))
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if string(data) != retrievertest.BinaryContent {
+				t.Error("Unmatching binary content, get :", string(data))
+			}
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+
+	// lock needs checksums of other platforms, read from their own .sha256 file
+	wantSum := sha256.Sum256([]byte("darwin archive"))
+	server := retrievertest.NewServer(t, map[string][]byte{
+		releasePath + "infracost-darwin-arm64.tar.gz.sha256": []byte(hex.EncodeToString(wantSum[:]) + "  infracost-darwin-arm64.tar.gz\n"),
+	})
+
+	conf := retrievertest.DirectConf(t, "infracost", server.URL)
+	sum, err := infracostretriever.Make(conf).Checksum(t.Context(), "0.10.41", "darwin", "arm64")
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if sum != hex.EncodeToString(wantSum[:]) {
+		t.Error("Unmatching results, get :", sum)
+	}
+}
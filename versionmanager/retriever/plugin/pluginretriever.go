@@ -0,0 +1,128 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pluginretriever
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+)
+
+const (
+	CmdInstall = "install"
+	CmdList    = "list"
+
+	// ProtocolVersion is sent in each Request, to be increased on incompatible changes.
+	ProtocolVersion = 1
+)
+
+var ErrPlugin = errors.New("plugin failure")
+
+// Request is written as JSON on plugin standard input (the command is also its first argument).
+type Request struct {
+	Arch            string `json:"arch"`
+	Command         string `json:"command"`
+	OS              string `json:"os"`
+	ProtocolVersion int    `json:"protocol_version"`
+	RemoteURL       string `json:"remote_url,omitempty"`
+	TargetDir       string `json:"target_dir,omitempty"` // install only, the plugin must write the tool executable in it
+	Tool            string `json:"tool"`
+	Version         string `json:"version,omitempty"` // install only
+}
+
+// Response is read as JSON from plugin standard output (its standard error is displayed as log).
+type Response struct {
+	Error    string   `json:"error,omitempty"`
+	Versions []string `json:"versions,omitempty"` // list only
+}
+
+// PluginRetriever delegates listing and installation to an external executable.
+type PluginRetriever struct {
+	conf   *config.Config
+	plugin *config.Plugin
+}
+
+func Make(conf *config.Config, plugin *config.Plugin) PluginRetriever {
+	return PluginRetriever{conf: conf, plugin: plugin}
+}
+
+func (r PluginRetriever) Install(ctx context.Context, version string, targetPath string) error {
+	if r.conf.Offline {
+		return download.OfflineError{Need: "installing " + r.plugin.Name + " with plugin"}
+	}
+
+	_, err := r.call(ctx, Request{Command: CmdInstall, TargetDir: targetPath, Version: version})
+
+	return err
+}
+
+func (r PluginRetriever) ListVersions(ctx context.Context) ([]string, error) {
+	response, err := r.call(ctx, Request{Command: CmdList})
+
+	return response.Versions, err
+}
+
+func (r PluginRetriever) call(ctx context.Context, request Request) (Response, error) {
+	request.Arch, request.OS, request.ProtocolVersion = r.conf.Arch, runtime.GOOS, ProtocolVersion
+	request.RemoteURL, request.Tool = r.plugin.RemoteURL, r.plugin.Name
+
+	dataRequest, err := json.Marshal(request)
+	if err != nil {
+		return Response{}, err
+	}
+
+	var outBuffer, errBuffer bytes.Buffer
+	cmd := exec.CommandContext(ctx, r.plugin.Path, request.Command)
+	cmd.Stdin = bytes.NewReader(dataRequest)
+	cmd.Stdout = &outBuffer
+	cmd.Stderr = &errBuffer
+
+	r.conf.Displayer.Log(hclog.Debug, "Call plugin", "path", r.plugin.Path, "request", string(dataRequest))
+	errRun := cmd.Run()
+
+	for line := range strings.Lines(errBuffer.String()) {
+		r.conf.Displayer.Display(strings.TrimRight(line, "\r\n"))
+	}
+
+	var response Response
+	if outBuffer.Len() != 0 {
+		if err = json.Unmarshal(outBuffer.Bytes(), &response); err != nil {
+			return Response{}, fmt.Errorf("%w : %s %s : invalid response : %w", ErrPlugin, r.plugin.Name, request.Command, err)
+		}
+	}
+
+	switch {
+	case response.Error != "":
+		return Response{}, fmt.Errorf("%w : %s %s : %s", ErrPlugin, r.plugin.Name, request.Command, response.Error)
+	case errRun != nil:
+		return Response{}, fmt.Errorf("%w : %s %s : %w", ErrPlugin, r.plugin.Name, request.Command, errRun)
+	}
+
+	return response, nil
+}
@@ -0,0 +1,132 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package pluginretriever_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	pluginretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/plugin"
+)
+
+const pluginModeEnvName = "TENV_TEST_PLUGIN_MODE"
+
+// the test binary acts as the plugin when called by PluginRetriever.
+func TestMain(m *testing.M) {
+	if os.Getenv(pluginModeEnvName) != "" {
+		os.Exit(fakePlugin())
+	}
+
+	os.Setenv(pluginModeEnvName, "1") //nolint
+	os.Exit(m.Run())
+}
+
+func TestListVersions(t *testing.T) {
+	t.Parallel()
+
+	versions, err := makeRetriever(t, "fake").ListVersions(t.Context())
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if !slices.Equal(versions, []string{"1.0.0", "1.1.0"}) {
+		t.Error("Unmatching results, get :", versions)
+	}
+}
+
+func TestInstall(t *testing.T) {
+	t.Parallel()
+
+	targetPath := t.TempDir()
+	if err := makeRetriever(t, "fake").Install(t.Context(), "1.1.0", targetPath); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(targetPath, "fake"))
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if string(data) != "fake 1.1.0 amd64" {
+		t.Error("Unmatching results, get :", string(data))
+	}
+}
+
+func TestInstallError(t *testing.T) {
+	t.Parallel()
+
+	if err := makeRetriever(t, "fake").Install(t.Context(), "2.0.0", t.TempDir()); !errors.Is(err, pluginretriever.ErrPlugin) {
+		t.Error("Should fail on unknown version, get :", err)
+	}
+
+	if _, err := makeRetriever(t, "crash").ListVersions(t.Context()); !errors.Is(err, pluginretriever.ErrPlugin) {
+		t.Error("Should fail on plugin crash, get :", err)
+	}
+}
+
+func fakePlugin() int {
+	var request pluginretriever.Request
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil || request.Command != os.Args[1] {
+		return 1
+	}
+
+	if request.Tool == "crash" {
+		os.Stderr.WriteString("crash\n") //nolint
+
+		return 2
+	}
+
+	var response pluginretriever.Response
+	switch {
+	case request.Command == pluginretriever.CmdList:
+		response.Versions = []string{"1.0.0", "1.1.0"}
+	case request.Version != "1.1.0":
+		response.Error = "unknown version " + request.Version
+	default:
+		data := []byte(request.Tool + " " + request.Version + " " + request.Arch)
+		if err := os.WriteFile(filepath.Join(request.TargetDir, request.Tool), data, 0o755); err != nil {
+			response.Error = err.Error()
+		}
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(response); err != nil {
+		return 1
+	}
+
+	return 0
+}
+
+func makeRetriever(t *testing.T, toolName string) pluginretriever.PluginRetriever {
+	t.Helper()
+
+	executablePath, err := os.Executable()
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	conf := &config.Config{Arch: "amd64", Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv}
+
+	return pluginretriever.Make(conf, &config.Plugin{Name: toolName, Path: executablePath})
+}
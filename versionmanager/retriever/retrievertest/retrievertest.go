@@ -0,0 +1,126 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+// Package retrievertest provides fixtures shared by retriever tests : a release server, archives and configuration.
+package retrievertest
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+const (
+	BinaryContent = "#!/bin/sh\necho ok"
+	TarGzFormat   = ".tar.gz"
+	ZipFormat     = ".zip"
+)
+
+// Archive builds a zip or tar.gz archive (depending on format) with BinaryContent in each path.
+func Archive(t *testing.T, format string, paths ...string) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	if format == ZipFormat {
+		zipWriter := zip.NewWriter(&buffer)
+		for _, path := range paths {
+			fileWriter, err := zipWriter.Create(path)
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if _, err = fileWriter.Write([]byte(BinaryContent)); err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+		}
+
+		if err := zipWriter.Close(); err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+
+		return buffer.Bytes()
+	}
+
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, path := range paths {
+		if err := tarWriter.WriteHeader(&tar.Header{Name: path, Mode: 0o755, Size: int64(len(BinaryContent))}); err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+
+		if _, err := tarWriter.Write([]byte(BinaryContent)); err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	return buffer.Bytes()
+}
+
+// DirectConf returns a configuration downloading toolName releases directly from remoteURL (with sha validation).
+func DirectConf(t *testing.T, toolName string, remoteURL string) *config.Config {
+	t.Helper()
+
+	rootPath := t.TempDir()
+	remoteConf := toolName + ":\n  url: " + remoteURL + "\n  install_mode: direct\n"
+	if err := os.WriteFile(filepath.Join(rootPath, "remote.yaml"), []byte(remoteConf), 0o600); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	return &config.Config{
+		Arch: "amd64", Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv,
+		RootPath: rootPath, Validation: config.ShaValidation, WorkPath: rootPath,
+	}
+}
+
+// NewServer starts a server returning files content by URL path (not found for others), closed with the test.
+func NewServer(t *testing.T, files map[string][]byte) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		data, ok := files[request.URL.Path]
+		if !ok {
+			http.NotFound(writer, request)
+
+			return
+		}
+
+		if _, err := writer.Write(data); err != nil {
+			t.Error("Unexpected error :", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
@@ -0,0 +1,247 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package signature
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/config/envname"
+	"github.com/tofuutils/tenv/v4/pkg/apimsg"
+	attestationcheck "github.com/tofuutils/tenv/v4/pkg/check/attestation"
+	cosigncheck "github.com/tofuutils/tenv/v4/pkg/check/cosign"
+	pgpcheck "github.com/tofuutils/tenv/v4/pkg/check/pgp"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/github"
+	githuburl "github.com/tofuutils/tenv/v4/pkg/github/url"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
+)
+
+const (
+	BundleSuffix = ".sigstore.json"
+)
+
+var (
+	ErrNoAttestation = errors.New("no build provenance attestation published for this version")
+	ErrNoSignature   = errors.New("no signature published for this version")
+
+	errNotFound = errors.New("not found")
+)
+
+// Signer describes the expected signer of a checksums file Sigstore bundle (published as checksums file name with BundleSuffix).
+type Signer struct {
+	IdentityRegexp string
+	Issuer         string
+}
+
+// GithubWorkflowSigner returns a Signer matching only the workflow file (in .github/workflows) of repository (like "owner/name")
+// run on tag.
+func GithubWorkflowSigner(repository string, workflow string, tag string) Signer {
+	identity := loghelper.Concat(githuburl.Base, "/", repository, "/", attestationcheck.WorkflowsDir, workflow, "@refs/tags/", tag)
+
+	return Signer{IdentityRegexp: loghelper.Concat("^", regexp.QuoteMeta(identity), "$"), Issuer: attestationcheck.GithubIssuer}
+}
+
+// BundleURL searches the Sigstore bundle asset of a GitHub release, return an empty string when it is not published.
+func BundleURL(ctx context.Context, conf *config.Config, remote config.RemoteConfig, tag string, sumsFileName string) (string, error) {
+	return OptionalAssetURL(ctx, conf, remote, tag, sumsFileName+BundleSuffix)
+}
+
+// OptionalAssetURL searches an asset of a GitHub release, return an empty string when it is not published.
+func OptionalAssetURL(ctx context.Context, conf *config.Config, remote config.RemoteConfig, tag string, assetName string) (string, error) {
+	assetURLs, err := github.AssetDownloadURL(ctx, tag, []string{assetName}, remote.GetRemoteURL(), conf.GithubToken, conf.GithubCache(), conf.Displayer.Display)
+	if err != nil {
+		if errors.Is(err, apimsg.ErrAsset) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return assetURLs[0], nil
+}
+
+// CheckAttestation verifies GitHub build provenance attestations of an archive with dataSHA256 sum when attestation validation
+// is configured for remote tool (missing attestations are handled with configured missing signature policy).
+// Attestations are fetched from GitHub API of repository (like "owner/name"), or from remote URL in API install mode,
+// and must be built by workflow file (in .github/workflows).
+//
+// Return the validation mode really applied and the attestation identity.
+func CheckAttestation(ctx context.Context, conf *config.Config, remote config.RemoteConfig, repository string, workflow string, dataSHA256 []byte) (config.ValidationMode, string, error) {
+	validation, strict, err := conf.GetValidation(remote)
+	if err != nil || validation != config.AttestationValidation {
+		return validation, "", err
+	}
+
+	repositoryURL := githuburl.Default + repository
+	if remote.GetInstallMode() == config.ModeAPI {
+		repositoryURL = strings.TrimSuffix(remote.GetRemoteURL(), githuburl.SlashReleasesSuffix)
+	}
+
+	conf.Displayer.Display("Fetching build provenance attestations from " + repositoryURL)
+
+	dataBundles, err := github.Attestations(ctx, repositoryURL, hex.EncodeToString(dataSHA256), conf.GithubToken, conf.GithubCache())
+	if err != nil {
+		return 0, "", err
+	}
+
+	if len(dataBundles) == 0 {
+		return missingSig(conf, strict, ErrNoAttestation)
+	}
+
+	trustedRoot, err := cosigncheck.TrustedRoot(conf.SigstoreRoot, conf.SigstoreCachePath(), conf.Offline, conf.HTTPClient)
+	if err != nil {
+		return 0, "", err
+	}
+
+	identity, err := attestationcheck.Check(dataSHA256, dataBundles, repository, workflow, trustedRoot)
+
+	return config.AttestationValidation, manifest.AttestationIdentity(identity), err
+}
+
+// CheckKeyless verifies the cosign keyless signature of dataSums (as produced by "cosign sign-blob --output-signature --output-certificate")
+// when signature validation is configured for remote tool (empty or not found signature and certificate URLs are handled like in CheckSums).
+//
+// Return the validation mode really applied and the signature identity.
+func CheckKeyless(ctx context.Context, conf *config.Config, remote config.RemoteConfig, dataSums []byte, sigURL string, certURL string, signer Signer, options []download.RequestOption) (config.ValidationMode, string, error) {
+	validation, strict, err := conf.GetValidation(remote)
+	if err != nil || validation != config.SignValidation {
+		return validation, "", err
+	}
+
+	if sigURL == "" || certURL == "" {
+		return missingSig(conf, strict, ErrNoSignature)
+	}
+
+	dataSig, err := conf.DownloadCache().Bytes(ctx, sigURL, conf.Displayer.Display, checkFound, options...)
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			return missingSig(conf, strict, ErrNoSignature)
+		}
+
+		return 0, "", err
+	}
+
+	dataCert, err := conf.DownloadCache().Bytes(ctx, certURL, conf.Displayer.Display, download.NoCheck, options...)
+	if err != nil {
+		return 0, "", err
+	}
+
+	// cosign executable also checks Rekor transparency log inclusion, unlike in process check
+	err = cosigncheck.CheckRegexp(ctx, dataSums, dataSig, dataCert, signer.IdentityRegexp, signer.Issuer, conf.Displayer)
+	if err == nil || !errors.Is(err, cosigncheck.ErrNotInstalled) {
+		return config.SignValidation, manifest.CosignIdentity(signer.IdentityRegexp, signer.Issuer), err
+	}
+
+	trustedRoot, err := cosigncheck.TrustedRoot(conf.SigstoreRoot, conf.SigstoreCachePath(), conf.Offline, conf.HTTPClient)
+	if err != nil {
+		return 0, "", err
+	}
+
+	identity, err := cosigncheck.CheckNativeRegexp(dataSums, dataSig, dataCert, signer.IdentityRegexp, signer.Issuer, trustedRoot)
+
+	return config.SignNoTlogValidation, manifest.CosignIdentity(identity, signer.Issuer), err
+}
+
+// CheckPGP verifies dataSig with keys of toolName trust store, or when it is empty with keys from keyPathOrURL.
+//
+// Return the fingerprint of the key validating the signature.
+func CheckPGP(ctx context.Context, conf *config.Config, toolName string, keyPathOrURL string, data []byte, dataSig []byte) (string, error) {
+	keys, err := conf.PGPKeyStore(toolName).Keys(conf.Displayer.Display)
+	if err != nil {
+		return "", err
+	}
+
+	if len(keys) == 0 {
+		dataPublicKey, err := download.GetPGPKey(ctx, keyPathOrURL, conf.Displayer.Display)
+		if err != nil {
+			return "", err
+		}
+
+		if keys, err = pgpcheck.ParseKeys(dataPublicKey); err != nil {
+			return "", err
+		}
+	}
+
+	return pgpcheck.VerifyKeys(data, dataSig, keys)
+}
+
+// CheckSums verifies the Sigstore bundle of dataSums when signature validation is configured for remote tool
+// (empty bundleURL or not found one are handled with configured missing signature policy, and refused with strict validation).
+//
+// Return the validation mode really applied and the signature identity.
+func CheckSums(ctx context.Context, conf *config.Config, remote config.RemoteConfig, dataSums []byte, bundleURL string, signer Signer, options []download.RequestOption) (config.ValidationMode, string, error) {
+	validation, strict, err := conf.GetValidation(remote)
+	if err != nil || validation != config.SignValidation {
+		return validation, "", err
+	}
+
+	if bundleURL == "" {
+		return missingSig(conf, strict, ErrNoSignature)
+	}
+
+	dataBundle, err := conf.DownloadCache().Bytes(ctx, bundleURL, conf.Displayer.Display, checkFound, options...)
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			return missingSig(conf, strict, ErrNoSignature)
+		}
+
+		return 0, "", err
+	}
+
+	trustedRoot, err := cosigncheck.TrustedRoot(conf.SigstoreRoot, conf.SigstoreCachePath(), conf.Offline, conf.HTTPClient)
+	if err != nil {
+		return 0, "", err
+	}
+
+	sum := sha256.Sum256(dataSums)
+	identity, err := cosigncheck.CheckBundle(sum[:], dataBundle, signer.IdentityRegexp, signer.Issuer, trustedRoot)
+
+	return config.SignValidation, manifest.CosignIdentity(identity, signer.Issuer), err
+}
+
+func checkFound(response *http.Response) error {
+	if response.StatusCode == http.StatusNotFound {
+		return errNotFound
+	}
+
+	return nil
+}
+
+func missingSig(conf *config.Config, strict bool, errMissing error) (config.ValidationMode, string, error) {
+	if strict {
+		return 0, "", fmt.Errorf("%w (strict validation)", errMissing)
+	}
+
+	if conf.MissingSig == config.FailMissingSig {
+		return 0, "", fmt.Errorf("%w (%s is %s)", errMissing, envname.TenvMissingSig, conf.MissingSig)
+	}
+
+	conf.Displayer.Display(loghelper.Concat("Warning, ", errMissing.Error(), ", only checksum is checked"))
+
+	return config.ShaValidation, "", nil
+}
@@ -0,0 +1,235 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package signature_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+	attestationcheck "github.com/tofuutils/tenv/v4/pkg/check/attestation"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/versionmanager/retriever/signature"
+)
+
+var (
+	dataSums = []byte("0123 terragrunt_linux_amd64\n")
+	signer   = signature.GithubWorkflowSigner("gruntwork-io/terragrunt", config.DefaultReleaseWorkflow, "v0.1.0")
+	sum      = sha256.Sum256(dataSums)
+	sumHex   = hex.EncodeToString(sum[:])
+)
+
+func TestGithubWorkflowSigner(t *testing.T) {
+	t.Parallel()
+
+	identityRegexp := regexp.MustCompile(signature.GithubWorkflowSigner("owner/tool.name", "release.yml", "v1.0.0").IdentityRegexp)
+
+	tests := []struct {
+		identity string
+		want     bool
+	}{
+		{identity: "https://github.com/owner/tool.name/.github/workflows/release.yml@refs/tags/v1.0.0", want: true},
+		{identity: "https://github.com/owner/toolxname/.github/workflows/release.yml@refs/tags/v1.0.0"},
+		{identity: "https://github.com/owner/tool.name/.github/workflows/other.yml@refs/tags/v1.0.0"},
+		{identity: "https://github.com/owner/tool.name/.github/workflows/release.yml@refs/heads/main"},
+		{identity: "https://github.com/owner/tool.name/.github/workflows/release.yml@refs/tags/v1.0.0-rc"},
+	}
+
+	for _, tt := range tests {
+		if got := identityRegexp.MatchString(tt.identity); got != tt.want {
+			t.Error("Unmatching results for", tt.identity, ", expected", tt.want, "get", got)
+		}
+	}
+}
+
+func TestCheckSums(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/present.sigstore.json" {
+			writer.Write([]byte("{}")) //nolint
+
+			return
+		}
+
+		writer.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name       string
+		bundleURL  string
+		missingSig config.MissingSigPolicy
+		strict     bool
+		validation config.ValidationMode
+		wantErr    error
+		wantMode   config.ValidationMode
+	}{
+		{name: "ShaValidation", bundleURL: server.URL + "/present.sigstore.json", validation: config.ShaValidation, wantMode: config.ShaValidation},
+		{name: "NoBundleWarn", validation: config.SignValidation, wantMode: config.ShaValidation},
+		{name: "NoBundleFail", missingSig: config.FailMissingSig, validation: config.SignValidation, wantErr: signature.ErrNoSignature},
+		{name: "NotFoundWarn", bundleURL: server.URL + "/missing.sigstore.json", validation: config.SignValidation, wantMode: config.ShaValidation},
+		{name: "NotFoundFail", bundleURL: server.URL + "/missing.sigstore.json", missingSig: config.FailMissingSig, validation: config.SignValidation, wantErr: signature.ErrNoSignature},
+		{name: "NotFoundStrict", bundleURL: server.URL + "/missing.sigstore.json", strict: true, validation: config.SignValidation, wantErr: signature.ErrNoSignature},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := &config.Config{Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv, MissingSig: test.missingSig, StrictValidation: test.strict, Validation: test.validation}
+			mode, identity, err := signature.CheckSums(t.Context(), conf, conf.Tg, dataSums, test.bundleURL, signer, nil)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatal("Unexpected error, expected", test.wantErr, "get :", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if mode != test.wantMode || identity != "" {
+				t.Error("Unmatching results, expected", test.wantMode, "without identity, get", mode, identity)
+			}
+		})
+	}
+
+	t.Run("ErrorTrustedRoot", func(t *testing.T) {
+		t.Parallel()
+
+		conf := &config.Config{
+			Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv, MissingSig: config.FailMissingSig,
+			SigstoreRoot: filepath.Join(t.TempDir(), "trusted_root.json"), Validation: config.SignValidation,
+		}
+		_, _, err := signature.CheckSums(t.Context(), conf, conf.Tg, dataSums, server.URL+"/present.sigstore.json", signer, nil)
+		if err == nil || errors.Is(err, signature.ErrNoSignature) {
+			t.Error("Should fail on unreadable trusted root, get :", err)
+		}
+	})
+}
+
+func TestCheckKeylessNative(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("cosign"); err == nil {
+		t.Skip("cosign executable is used when installed")
+	}
+
+	testdataPath := filepath.Join("..", "..", "..", "pkg", "check", "cosign", "testdata")
+	dataTofuSums, err := os.ReadFile(filepath.Join(testdataPath, "tofu_1.6.0_SHA256SUMS"))
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	server := httptest.NewServer(http.FileServer(http.Dir(testdataPath)))
+	t.Cleanup(server.Close)
+
+	conf := &config.Config{
+		Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv,
+		SigstoreRoot: filepath.Join(testdataPath, "trusted_root.json"), Validation: config.SignValidation,
+	}
+	tofuSigner := signature.Signer{IdentityRegexp: "^https://github\\.com/opentofu/opentofu/", Issuer: attestationcheck.GithubIssuer}
+	sigURL, certURL := server.URL+"/tofu_1.6.0_SHA256SUMS.sig", server.URL+"/tofu_1.6.0_SHA256SUMS.pem"
+
+	// without Rekor inclusion check, the applied validation is weaker than requested one
+	mode, _, err := signature.CheckKeyless(t.Context(), conf, conf.Tofu, dataTofuSums, sigURL, certURL, tofuSigner, nil)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if mode != config.SignNoTlogValidation {
+		t.Error("Unmatching results, expected", config.SignNoTlogValidation, "get", mode)
+	}
+
+	conf.StrictValidation = true
+	if err = conf.CheckValidation(conf.Tofu, mode); !errors.Is(err, config.ErrValidationDowngrade) {
+		t.Error("Should fail on downgraded validation, get :", err)
+	}
+}
+
+func TestCheckAttestation(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/repos/owner/attested/attestations/sha256:"+sumHex {
+			writer.Write([]byte(`{"attestations":[{"bundle":{}}]}`)) //nolint
+
+			return
+		}
+
+		writer.WriteHeader(http.StatusNotFound)
+		writer.Write([]byte(`{"message":"Not Found"}`)) //nolint
+	}))
+	t.Cleanup(server.Close)
+
+	tests := []struct {
+		name       string
+		missingSig config.MissingSigPolicy
+		repository string
+		strict     bool
+		validation config.ValidationMode
+		wantErr    error
+		wantMode   config.ValidationMode
+	}{
+		{name: "SignValidation", repository: "attested", validation: config.SignValidation, wantMode: config.SignValidation},
+		{name: "MissingWarn", repository: "missing", validation: config.AttestationValidation, wantMode: config.ShaValidation},
+		{name: "MissingFail", missingSig: config.FailMissingSig, repository: "missing", validation: config.AttestationValidation, wantErr: signature.ErrNoAttestation},
+		{name: "MissingStrict", repository: "missing", strict: true, validation: config.AttestationValidation, wantErr: signature.ErrNoAttestation},
+		{name: "Invalid", repository: "attested", validation: config.AttestationValidation, wantErr: attestationcheck.ErrCheck},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := &config.Config{
+				Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv, GithubCacheTTL: -1, MissingSig: test.missingSig,
+				SigstoreRoot: filepath.Join("..", "..", "..", "pkg", "check", "cosign", "testdata", "trusted_root.json"), StrictValidation: test.strict, Validation: test.validation,
+			}
+			remote := config.RemoteConfig{RemoteURL: server.URL + "/repos/owner/" + test.repository + "/releases"}
+			mode, _, err := signature.CheckAttestation(t.Context(), conf, remote, "owner/"+test.repository, config.DefaultReleaseWorkflow, sum[:])
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatal("Unexpected error, expected", test.wantErr, "get :", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if mode != test.wantMode {
+				t.Error("Unmatching results, expected", test.wantMode, "get", mode)
+			}
+		})
+	}
+}
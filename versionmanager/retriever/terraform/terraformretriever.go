@@ -20,6 +20,7 @@ package terraformretriever
 
 import (
 	"context"
+	"encoding/hex"
 	"net/url"
 	"runtime"
 	"strings"
@@ -30,13 +31,17 @@ import (
 	"github.com/tofuutils/tenv/v4/config/cmdconst"
 	"github.com/tofuutils/tenv/v4/config/envname"
 	"github.com/tofuutils/tenv/v4/pkg/apimsg"
-	pgpcheck "github.com/tofuutils/tenv/v4/pkg/check/pgp"
 	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
 	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
 	"github.com/tofuutils/tenv/v4/pkg/pathfilter"
 	"github.com/tofuutils/tenv/v4/pkg/uncompress"
 	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
+	"github.com/tofuutils/tenv/v4/versionmanager/projectlock"
 	htmlretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/html"
+	indexretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/index"
+	"github.com/tofuutils/tenv/v4/versionmanager/retriever/signature"
 	releaseapi "github.com/tofuutils/tenv/v4/versionmanager/retriever/terraform/api"
 )
 
@@ -53,10 +58,14 @@ func Make(conf *config.Config) TerraformRetriever {
 	return TerraformRetriever{conf: conf}
 }
 
-func (r TerraformRetriever) Install(ctx context.Context, version string, targetPath string) error {
+func (r TerraformRetriever) Checksum(ctx context.Context, version string, goos string, arch string) (string, error) {
 	err := r.conf.InitRemoteConf()
 	if err != nil {
-		return err
+		return "", err
+	}
+
+	if ctx, err = r.conf.DownloadContext(ctx, r.conf.Tf); err != nil {
+		return "", err
 	}
 
 	// assume that terraform  version do not start with a 'v'
@@ -64,74 +73,85 @@ func (r TerraformRetriever) Install(ctx context.Context, version string, targetP
 		version = version[1:]
 	}
 
-	baseVersionURL, err := url.JoinPath(r.conf.Tf.GetRemoteURL(), cmdconst.TerraformName, version)
+	requestOptions := indexretriever.RequestOptions(r.conf.Tf.GetInstallMode(), r.conf.Tf.GetRemoteURL(), config.GetBasicAuthOption(r.conf.Getenv, envname.TfRemoteUser, envname.TfRemotePass))
+	fileName, assetURLs, err := r.assetURLs(ctx, version, goos, arch, requestOptions)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	requestOptions := config.GetBasicAuthOption(r.conf.Getenv, envname.TfRemoteUser, envname.TfRemotePass)
+	dataSums, err := r.conf.DownloadCache().Bytes(ctx, assetURLs[1], r.conf.Displayer.Display, download.NoCheck, requestOptions...)
+	if err != nil {
+		return "", err
+	}
 
-	var fileName, shaFileName, shaSigFileName, downloadURL, downloadSumsURL, downloadSumsSigURL string
-	switch r.conf.Tf.GetInstallMode() {
-	case config.InstallModeDirect:
-		fileName, shaFileName, shaSigFileName = buildAssetNames(version, r.conf.Arch)
-		if r.conf.Displayer.IsDebug() {
-			r.conf.Displayer.Log(hclog.Debug, apimsg.MsgSearch, apimsg.AssetsName, []string{fileName, shaFileName, shaSigFileName})
-		}
+	validation, _, err := r.conf.GetValidation(r.conf.Tf)
+	if err != nil {
+		return "", err
+	}
 
-		assetURLs, err := htmlretriever.BuildAssetURLs(baseVersionURL, fileName, shaFileName, shaSigFileName)
-		if err != nil {
-			return err
+	if validation == config.SignValidation || validation == config.AttestationValidation { // no attestation published outside GitHub
+		if _, err = r.checkSig(ctx, dataSums, assetURLs[2], requestOptions); err != nil {
+			return "", err
 		}
+	}
 
-		downloadURL, downloadSumsURL, downloadSumsSigURL = assetURLs[0], assetURLs[1], assetURLs[2]
-	case config.ModeAPI:
-		versionURL, err := url.JoinPath(baseVersionURL, indexJSON)
-		if err != nil {
-			return err
-		}
+	dataSum, err := sha256check.Extract(dataSums, fileName)
+	if err != nil {
+		return "", err
+	}
 
-		r.conf.Displayer.Display(apimsg.MsgFetchRelease + versionURL)
+	return hex.EncodeToString(dataSum), nil
+}
 
-		value, err := download.JSON(ctx, versionURL, download.NoDisplay, download.NoCheck, requestOptions...)
-		if err != nil {
-			return err
-		}
+func (r TerraformRetriever) Install(ctx context.Context, version string, targetPath string) error {
+	_, err := r.InstallWithManifest(ctx, version, targetPath)
 
-		fileName, downloadURL, shaFileName, shaSigFileName, err = releaseapi.ExtractAssetURLs(runtime.GOOS, r.conf.Arch, value)
-		if err != nil {
-			return err
-		}
+	return err
+}
 
-		if r.conf.Displayer.IsDebug() {
-			r.conf.Displayer.Log(hclog.Debug, apimsg.MsgSearch, apimsg.AssetsName, []string{fileName, shaFileName, shaSigFileName})
-		}
+func (r TerraformRetriever) InstallWithManifest(ctx context.Context, version string, targetPath string) (manifest.Manifest, error) {
+	err := r.conf.InitRemoteConf()
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
 
-		assetURLs, err := htmlretriever.BuildAssetURLs(baseVersionURL, shaFileName, shaSigFileName)
-		if err != nil {
-			return err
-		}
+	if ctx, err = r.conf.DownloadContext(ctx, r.conf.Tf); err != nil {
+		return manifest.Manifest{}, err
+	}
 
-		downloadSumsURL, downloadSumsSigURL = assetURLs[0], assetURLs[1]
-	default:
-		return config.ErrInstallMode
+	// assume that terraform  version do not start with a 'v'
+	if version[0] == 'v' {
+		version = version[1:]
 	}
 
-	assetURLs, err := download.ApplyURLTransformer(r.conf.Tf.GetRewriteRule(), downloadURL, downloadSumsURL, downloadSumsSigURL)
+	requestOptions := indexretriever.RequestOptions(r.conf.Tf.GetInstallMode(), r.conf.Tf.GetRemoteURL(), config.GetBasicAuthOption(r.conf.Getenv, envname.TfRemoteUser, envname.TfRemotePass))
+	fileName, assetURLs, err := r.assetURLs(ctx, version, runtime.GOOS, r.conf.Arch, requestOptions)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	file, err := r.conf.DownloadCache().File(ctx, assetURLs[0], r.conf.Displayer.Display, loghelper.ProgressFunc(r.conf.Displayer), download.NoCheck, requestOptions...)
 	if err != nil {
-		return err
+		return manifest.Manifest{}, err
 	}
+	defer file.Release()
 
-	data, err := download.Bytes(ctx, assetURLs[0], r.conf.Displayer.Display, download.NoCheck, requestOptions...)
+	validation, identity, err := r.checkSumAndSig(ctx, fileName, file.SHA256, assetURLs[1], assetURLs[2], requestOptions)
 	if err != nil {
-		return err
+		return manifest.Manifest{}, err
+	}
+
+	if err = r.conf.CheckValidation(r.conf.Tf, validation); err != nil {
+		return manifest.Manifest{}, err
 	}
 
-	if err = r.checkSumAndSig(ctx, fileName, data, assetURLs[1], assetURLs[2], requestOptions); err != nil {
-		return err
+	if err = projectlock.Check(r.conf, cmdconst.TerraformName, version, runtime.GOOS, r.conf.Arch, file.SHA256); err != nil {
+		return manifest.Manifest{}, err
 	}
 
-	return uncompress.ToDir(data, fileName, targetPath, pathfilter.NameEqual(winbin.GetBinaryName(cmdconst.TerraformName)))
+	installManifest := manifest.Manifest{ArchiveSHA256: hex.EncodeToString(file.SHA256), SignatureIdentity: identity, SourceURL: assetURLs[0], Validation: validation}
+
+	return installManifest, uncompress.ToDir(file.Path, fileName, targetPath, pathfilter.NameEqual(winbin.GetBinaryName(cmdconst.TerraformName)))
 }
 
 func (r TerraformRetriever) ListVersions(ctx context.Context) ([]string, error) {
@@ -140,12 +160,16 @@ func (r TerraformRetriever) ListVersions(ctx context.Context) ([]string, error)
 		return nil, err
 	}
 
+	if ctx, err = r.conf.DownloadContext(ctx, r.conf.Tf); err != nil {
+		return nil, err
+	}
+
 	baseURL, err := url.JoinPath(r.conf.Tf.GetListURL(), cmdconst.TerraformName)
 	if err != nil {
 		return nil, err
 	}
 
-	requestOptions := config.GetBasicAuthOption(r.conf.Getenv, envname.TfRemoteUser, envname.TfRemotePass)
+	requestOptions := indexretriever.RequestOptions(r.conf.Tf.GetListMode(), r.conf.Tf.GetListURL(), config.GetBasicAuthOption(r.conf.Getenv, envname.TfRemoteUser, envname.TfRemotePass))
 
 	switch r.conf.Tf.GetListMode() {
 	case config.ListModeHTML:
@@ -166,50 +190,136 @@ func (r TerraformRetriever) ListVersions(ctx context.Context) ([]string, error)
 		}
 
 		return releaseapi.ExtractReleases(value)
+	case config.ModeIndex:
+		indexURL, err := indexretriever.IndexURL(r.conf.Tf.GetListURL(), cmdconst.TerraformName)
+		if err != nil {
+			return nil, err
+		}
+
+		r.conf.Displayer.Display(apimsg.MsgFetchAllReleases + indexURL)
+
+		return indexretriever.ListReleases(ctx, indexURL, requestOptions)
 	default:
 		return nil, config.ErrListMode
 	}
 }
 
-func (r TerraformRetriever) checkSumAndSig(ctx context.Context, fileName string, data []byte, downloadSumsURL string, downloadSumsSigURL string, options []download.RequestOption) error {
-	if r.conf.Validation == config.NoValidation {
-		return nil
-	}
-
-	dataSums, err := download.Bytes(ctx, downloadSumsURL, r.conf.Displayer.Display, download.NoCheck, options...)
+// return archive file name and urls (archive, checksums file and its signature).
+func (r TerraformRetriever) assetURLs(ctx context.Context, version string, goos string, arch string, requestOptions []download.RequestOption) (string, []string, error) {
+	baseVersionURL, err := url.JoinPath(r.conf.Tf.GetRemoteURL(), cmdconst.TerraformName, version)
 	if err != nil {
-		return err
+		return "", nil, err
 	}
 
-	if err = sha256check.Check(data, dataSums, fileName); err != nil {
-		return err
+	var fileName, shaFileName, shaSigFileName, downloadURL, downloadSumsURL, downloadSumsSigURL string
+	switch r.conf.Tf.GetInstallMode() {
+	case config.InstallModeDirect:
+		fileName, shaFileName, shaSigFileName = buildAssetNames(version, goos, arch)
+		if r.conf.Displayer.IsDebug() {
+			r.conf.Displayer.Log(hclog.Debug, apimsg.MsgSearch, apimsg.AssetsName, []string{fileName, shaFileName, shaSigFileName})
+		}
+
+		assetURLs, err := htmlretriever.BuildAssetURLs(baseVersionURL, fileName, shaFileName, shaSigFileName)
+		if err != nil {
+			return "", nil, err
+		}
+
+		downloadURL, downloadSumsURL, downloadSumsSigURL = assetURLs[0], assetURLs[1], assetURLs[2]
+	case config.ModeAPI:
+		versionURL, err := url.JoinPath(baseVersionURL, indexJSON)
+		if err != nil {
+			return "", nil, err
+		}
+
+		r.conf.Displayer.Display(apimsg.MsgFetchRelease + versionURL)
+
+		value, err := download.JSON(ctx, versionURL, download.NoDisplay, download.NoCheck, requestOptions...)
+		if err != nil {
+			return "", nil, err
+		}
+
+		fileName, downloadURL, shaFileName, shaSigFileName, err = releaseapi.ExtractAssetURLs(goos, arch, value)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if r.conf.Displayer.IsDebug() {
+			r.conf.Displayer.Log(hclog.Debug, apimsg.MsgSearch, apimsg.AssetsName, []string{fileName, shaFileName, shaSigFileName})
+		}
+
+		assetURLs, err := htmlretriever.BuildAssetURLs(baseVersionURL, shaFileName, shaSigFileName)
+		if err != nil {
+			return "", nil, err
+		}
+
+		downloadSumsURL, downloadSumsSigURL = assetURLs[0], assetURLs[1]
+	case config.ModeIndex:
+		indexURL, err := indexretriever.IndexURL(r.conf.Tf.GetRemoteURL(), cmdconst.TerraformName)
+		if err != nil {
+			return "", nil, err
+		}
+
+		r.conf.Displayer.Display(apimsg.MsgFetchRelease + indexURL)
+
+		var assetURLs []string
+		if fileName, assetURLs, err = indexretriever.AssetURLs(ctx, indexURL, version, goos, arch, requestOptions); err != nil {
+			return "", nil, err
+		}
+
+		downloadURL, downloadSumsURL, downloadSumsSigURL = assetURLs[0], assetURLs[1], assetURLs[2]
+	default:
+		return "", nil, config.ErrInstallMode
 	}
 
-	if r.conf.Validation == config.ShaValidation {
-		return nil
+	assetURLs, err := download.ApplyURLTransformer(r.conf.Tf.GetRewriteRule(), downloadURL, downloadSumsURL, downloadSumsSigURL)
+
+	return fileName, assetURLs, err
+}
+
+func (r TerraformRetriever) checkSumAndSig(ctx context.Context, fileName string, sum []byte, downloadSumsURL string, downloadSumsSigURL string, options []download.RequestOption) (config.ValidationMode, string, error) {
+	validation, _, err := r.conf.GetValidation(r.conf.Tf)
+	if err != nil || validation == config.NoValidation {
+		return config.NoValidation, "", err
 	}
 
-	dataSumsSig, err := download.Bytes(ctx, downloadSumsSigURL, r.conf.Displayer.Display, download.NoCheck, options...)
+	dataSums, err := r.conf.DownloadCache().Bytes(ctx, downloadSumsURL, r.conf.Displayer.Display, download.NoCheck, options...)
 	if err != nil {
-		return err
+		return 0, "", err
 	}
 
-	dataPublicKey, err := download.GetPGPKey(ctx, r.conf.TfKeyPathOrURL, r.conf.Displayer.Display)
+	if err = sha256check.CheckSum(sum, dataSums, fileName); err != nil {
+		return 0, "", err
+	}
+
+	if validation == config.ShaValidation {
+		return config.ShaValidation, "", nil
+	}
+
+	// Terraform is not released on GitHub (no build provenance attestation), signature is checked for AttestationValidation too
+	identity, err := r.checkSig(ctx, dataSums, downloadSumsSigURL, options)
+
+	return config.SignValidation, identity, err
+}
+
+func (r TerraformRetriever) checkSig(ctx context.Context, dataSums []byte, downloadSumsSigURL string, options []download.RequestOption) (string, error) {
+	dataSumsSig, err := r.conf.DownloadCache().Bytes(ctx, downloadSumsSigURL, r.conf.Displayer.Display, download.NoCheck, options...)
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	return pgpcheck.Check(dataSums, dataSumsSig, dataPublicKey)
+	fingerprint, err := signature.CheckPGP(ctx, r.conf, cmdconst.TerraformName, r.conf.TfKeyPathOrURL, dataSums, dataSumsSig)
+
+	return manifest.PGPIdentity(fingerprint), err
 }
 
-func buildAssetNames(version string, arch string) (string, string, string) {
+func buildAssetNames(version string, goos string, arch string) (string, string, string) {
 	var nameBuilder strings.Builder
 	nameBuilder.WriteString(baseFileName)
 	nameBuilder.WriteString(version)
 	nameBuilder.WriteByte('_')
 	sumsAssetName := nameBuilder.String() + "SHA256SUMS"
 
-	nameBuilder.WriteString(runtime.GOOS)
+	nameBuilder.WriteString(goos)
 	nameBuilder.WriteByte('_')
 	nameBuilder.WriteString(arch)
 	nameBuilder.WriteString(".zip")
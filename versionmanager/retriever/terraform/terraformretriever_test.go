@@ -0,0 +1,201 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package terraformretriever_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/apimsg"
+	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	terraformretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/terraform"
+)
+
+const (
+	binaryContent = "#!/bin/sh\necho terraform"
+	indexContent  = `{"name":"terraform","versions":{
+"1.5.7":{"builds":[{"arch":"amd64","filename":"terraform_1.5.7_%OS%_amd64.zip","os":"%OS%","url":"1.5.7/terraform_1.5.7_%OS%_amd64.zip"}],
+"name":"terraform","shasums":"terraform_1.5.7_SHA256SUMS","shasums_signature":"terraform_1.5.7_SHA256SUMS.sig","version":"1.5.7"},
+"1.6.0":{"builds":[{"arch":"amd64","filename":"terraform_1.6.0_%OS%_amd64.zip","os":"%OS%","url":"1.6.0/terraform_1.6.0_%OS%_amd64.zip"}],
+"name":"terraform","shasums":"terraform_1.6.0_SHA256SUMS","shasums_signature":"terraform_1.6.0_SHA256SUMS.sig","version":"1.6.0"}}}`
+)
+
+func TestIndexMode(t *testing.T) {
+	t.Parallel()
+
+	mirrorPath := t.TempDir()
+	dataArchive := buildArchive(t)
+	sum := sha256.Sum256(dataArchive)
+	wrongSum := sha256.Sum256([]byte("other"))
+	writeMirrorFile(t, mirrorPath, "terraform/index.json", []byte(strings.ReplaceAll(indexContent, "%OS%", runtime.GOOS)))
+	for _, version := range []string{"1.5.7", "1.6.0"} {
+		assetName := "terraform_" + version + "_" + runtime.GOOS + "_amd64.zip"
+		writeMirrorFile(t, mirrorPath, "terraform/"+version+"/"+assetName, dataArchive)
+
+		dataSum := sum
+		if version == "1.6.0" {
+			dataSum = wrongSum
+		}
+		writeMirrorFile(t, mirrorPath, "terraform/"+version+"/terraform_"+version+"_SHA256SUMS", []byte(hex.EncodeToString(dataSum[:])+"  "+assetName+"\n"))
+	}
+
+	rootPath := t.TempDir()
+	remoteConf := "terraform:\n  url: " + fileURL(mirrorPath) + "\n  install_mode: index\n  list_mode: index\n"
+	writeMirrorFile(t, rootPath, "remote.yaml", []byte(remoteConf))
+
+	// a local mirror stays usable in offline mode
+	conf := &config.Config{
+		Arch: "amd64", Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv, Offline: true,
+		RootPath: rootPath, Validation: config.ShaValidation, WorkPath: rootPath,
+	}
+	retriever := terraformretriever.Make(conf)
+
+	versions, err := retriever.ListVersions(t.Context())
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	slices.Sort(versions)
+	if !slices.Equal(versions, []string{"1.5.7", "1.6.0"}) {
+		t.Error("Unmatching results, get :", versions)
+	}
+
+	targetPath := filepath.Join(rootPath, "1.5.7")
+	installManifest, err := retriever.InstallWithManifest(t.Context(), "1.5.7", targetPath)
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if installManifest.Validation != config.ShaValidation || installManifest.ArchiveSHA256 != hex.EncodeToString(sum[:]) {
+		t.Error("Unmatching manifest, get :", installManifest)
+	}
+
+	data, err := os.ReadFile(filepath.Join(targetPath, winbin.GetBinaryName("terraform")))
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if string(data) != binaryContent {
+		t.Error("Unmatching binary content, get :", string(data))
+	}
+
+	if err = retriever.Install(t.Context(), "1.6.0", filepath.Join(rootPath, "1.6.0")); !errors.Is(err, sha256check.ErrCheck) {
+		t.Error("Should fail on checksum mismatch, get :", err)
+	}
+
+	if err = retriever.Install(t.Context(), "1.7.0", filepath.Join(rootPath, "1.7.0")); !errors.Is(err, apimsg.ErrAsset) {
+		t.Error("Should fail on version missing from index, get :", err)
+	}
+}
+
+func TestIndexModeRemoteFileURL(t *testing.T) {
+	t.Parallel()
+
+	mirrorPath := t.TempDir()
+	assetName := "terraform_1.5.7_" + runtime.GOOS + "_amd64.zip"
+	writeMirrorFile(t, mirrorPath, assetName, buildArchive(t))
+
+	// an index served over HTTP must not make tenv read local files
+	localIndex := strings.ReplaceAll(indexContent, "%OS%", runtime.GOOS)
+	localIndex = strings.Replace(localIndex, "1.5.7/"+assetName, fileURL(mirrorPath)+"/"+assetName, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/terraform/index.json" {
+			http.NotFound(writer, request)
+
+			return
+		}
+
+		if _, err := writer.Write([]byte(localIndex)); err != nil {
+			t.Error("Unexpected error :", err)
+		}
+	}))
+	defer server.Close()
+
+	rootPath := t.TempDir()
+	remoteConf := "terraform:\n  url: " + server.URL + "\n  install_mode: index\n  list_mode: index\n"
+	writeMirrorFile(t, rootPath, "remote.yaml", []byte(remoteConf))
+
+	conf := &config.Config{
+		Arch: "amd64", Displayer: loghelper.InertDisplayer, Getenv: config.EmptyGetenv,
+		RootPath: rootPath, Validation: config.ShaValidation, WorkPath: rootPath,
+	}
+	retriever := terraformretriever.Make(conf)
+
+	if err := retriever.Install(t.Context(), "1.5.7", filepath.Join(rootPath, "1.5.7")); !errors.Is(err, download.ErrLocalFile) {
+		t.Error("Should fail on file URL declared by a remote index, get :", err)
+	}
+}
+
+func buildArchive(t *testing.T) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	fileWriter, err := zipWriter.Create(winbin.GetBinaryName("terraform"))
+	if err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if _, err = fileWriter.Write([]byte(binaryContent)); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err = zipWriter.Close(); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	return buffer.Bytes()
+}
+
+func fileURL(dirPath string) string {
+	slashPath := filepath.ToSlash(dirPath)
+	if !strings.HasPrefix(slashPath, "/") { // windows drive letter
+		slashPath = "/" + slashPath
+	}
+
+	return "file://" + slashPath
+}
+
+func writeMirrorFile(t *testing.T, dirPath string, name string, data []byte) {
+	t.Helper()
+
+	filePath := filepath.Join(dirPath, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+
+	if err := os.WriteFile(filePath, data, 0o600); err != nil {
+		t.Fatal("Unexpected error :", err)
+	}
+}
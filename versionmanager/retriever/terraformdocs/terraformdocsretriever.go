@@ -0,0 +1,69 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package terraformdocsretriever
+
+import (
+	"strings"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/config/cmdconst"
+	"github.com/tofuutils/tenv/v4/config/envname"
+	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	githubreleaseretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/githubrelease"
+)
+
+const (
+	baseFileName = cmdconst.TerraformDocsName + "-"
+	sumsSuffix   = ".sha256sum"
+)
+
+type TerraformDocsRetriever struct {
+	githubreleaseretriever.GithubReleaseRetriever
+}
+
+func Make(conf *config.Config) TerraformDocsRetriever {
+	return TerraformDocsRetriever{GithubReleaseRetriever: githubreleaseretriever.Make(conf, &conf.TfDocs, githubreleaseretriever.Tool{
+		AssetNames: buildAssetNames,
+		Name:       cmdconst.TerraformDocsName,
+		RemotePass: envname.TfDocsRemotePass,
+		RemoteUser: envname.TfDocsRemoteUser,
+		// terraform-docs organization has the same name than its repository.
+		Repository: cmdconst.TerraformDocsName + "/" + cmdconst.TerraformDocsName,
+	})}
+}
+
+// terraform-docs asset names contain the tag (with its 'v').
+func buildAssetNames(tag string, goos string, arch string) githubreleaseretriever.AssetNames {
+	var nameBuilder strings.Builder
+	nameBuilder.WriteString(baseFileName)
+	nameBuilder.WriteString(tag)
+	sumsAssetName := nameBuilder.String() + sumsSuffix
+
+	nameBuilder.WriteByte('-')
+	nameBuilder.WriteString(goos)
+	nameBuilder.WriteByte('-')
+	nameBuilder.WriteString(arch)
+	nameBuilder.WriteString(winbin.GetArchiveFormatForOS(goos))
+
+	return githubreleaseretriever.AssetNames{
+		Archive: nameBuilder.String(),
+		Binary:  winbin.GetBinaryName(cmdconst.TerraformDocsName),
+		Sums:    sumsAssetName,
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package terraformdocsretriever_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	"github.com/tofuutils/tenv/v4/versionmanager/retriever/retrievertest"
+	terraformdocsretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/terraformdocs"
+)
+
+const (
+	releasePath = "/terraform-docs/terraform-docs/releases/download/v0.19.0/"
+	sumsName    = "terraform-docs-v0.19.0.sha256sum"
+)
+
+// terraform-docs asset names contain the tag, with one checksums file per release and zip archives for windows.
+func TestInstall(t *testing.T) {
+	t.Parallel()
+
+	archiveFormat := winbin.GetArchiveFormatForOS(runtime.GOOS)
+	assetName := "terraform-docs-v0.19.0-" + runtime.GOOS + "-amd64" + archiveFormat
+	dataArchive := retrievertest.Archive(t, archiveFormat, winbin.GetBinaryName("terraform-docs"))
+	sum := sha256.Sum256(dataArchive)
+	otherSum := sha256.Sum256([]byte("other"))
+	dataSums := hex.EncodeToString(otherSum[:]) + "  terraform-docs-v0.19.0-other-amd64.tar.gz\n" + hex.EncodeToString(sum[:]) + "  " + assetName + "\n"
+
+	server := retrievertest.NewServer(t, map[string][]byte{
+		releasePath + assetName: dataArchive,
+		releasePath + sumsName:  []byte(dataSums),
+	})
+
+	for _, version := range []string{"0.19.0", "v0.19.0"} {
+		t.Run(version, func(t *testing.T) {
+			t.Parallel()
+
+			conf := retrievertest.DirectConf(t, "terraform-docs", server.URL)
+			targetPath := filepath.Join(conf.RootPath, "0.19.0")
+			installManifest, err := terraformdocsretriever.Make(conf).InstallWithManifest(t.Context(), version, targetPath)
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if installManifest.Validation != config.ShaValidation || installManifest.SourceURL != server.URL+releasePath+assetName {
+				t.Error("Unmatching manifest, get :", installManifest)
+			}
+
+			data, err := os.ReadFile(filepath.Join(targetPath, winbin.GetBinaryName("terraform-docs")))
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if string(data) != retrievertest.BinaryContent {
+				t.Error("Unmatching binary content, get :", string(data))
+			}
+		})
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	t.Parallel()
+
+	linuxSum := sha256.Sum256([]byte("linux archive"))
+	windowsSum := sha256.Sum256([]byte("windows archive"))
+	dataSums := hex.EncodeToString(linuxSum[:]) + "  terraform-docs-v0.19.0-linux-arm64.tar.gz\n" +
+		hex.EncodeToString(windowsSum[:]) + "  terraform-docs-v0.19.0-windows-arm64.zip\n"
+
+	server := retrievertest.NewServer(t, map[string][]byte{releasePath + sumsName: []byte(dataSums)})
+
+	retriever := terraformdocsretriever.Make(retrievertest.DirectConf(t, "terraform-docs", server.URL))
+	for goos, wantSum := range map[string][]byte{"linux": linuxSum[:], "windows": windowsSum[:]} {
+		sum, err := retriever.Checksum(t.Context(), "0.19.0", goos, "arm64")
+		if err != nil {
+			t.Fatal("Unexpected error :", err)
+		}
+
+		if sum != hex.EncodeToString(wantSum) {
+			t.Error("Unmatching results for", goos, ", get :", sum)
+		}
+	}
+}
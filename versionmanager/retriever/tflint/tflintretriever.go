@@ -0,0 +1,57 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tflintretriever
+
+import (
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/config/cmdconst"
+	"github.com/tofuutils/tenv/v4/config/envname"
+	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	githubreleaseretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/githubrelease"
+)
+
+const (
+	baseFileName = "tflint_"
+	shaFileName  = "checksums.txt"
+	zipSuffix    = ".zip"
+)
+
+type TflintRetriever struct {
+	githubreleaseretriever.GithubReleaseRetriever
+}
+
+func Make(conf *config.Config) TflintRetriever {
+	return TflintRetriever{GithubReleaseRetriever: githubreleaseretriever.Make(conf, &conf.Tflint, githubreleaseretriever.Tool{
+		AssetNames: buildAssetNames,
+		Keyless:    true,
+		Name:       cmdconst.TflintName,
+		RemotePass: envname.TflintRemotePass,
+		RemoteUser: envname.TflintRemoteUser,
+		Repository: "terraform-linters/" + cmdconst.TflintName,
+	})}
+}
+
+// tflint release assets are zip archives without version in their name, with one checksums file per release.
+func buildAssetNames(_ string, goos string, arch string) githubreleaseretriever.AssetNames {
+	return githubreleaseretriever.AssetNames{
+		Archive: baseFileName + goos + "_" + arch + zipSuffix,
+		Binary:  winbin.GetBinaryName(cmdconst.TflintName),
+		Sums:    shaFileName,
+	}
+}
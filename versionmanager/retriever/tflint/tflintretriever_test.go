@@ -0,0 +1,99 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package tflintretriever_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	"github.com/tofuutils/tenv/v4/versionmanager/retriever/retrievertest"
+	"github.com/tofuutils/tenv/v4/versionmanager/retriever/signature"
+	tflintretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/tflint"
+)
+
+const releasePath = "/terraform-linters/tflint/releases/download/v0.55.0/"
+
+func TestInstall(t *testing.T) {
+	t.Parallel()
+
+	assetName := "tflint_" + runtime.GOOS + "_amd64.zip"
+	dataArchive := retrievertest.Archive(t, retrievertest.ZipFormat, winbin.GetBinaryName("tflint"))
+	sum := sha256.Sum256(dataArchive)
+
+	server := retrievertest.NewServer(t, map[string][]byte{
+		releasePath + assetName:       dataArchive,
+		releasePath + "checksums.txt": []byte(hex.EncodeToString(sum[:]) + "  " + assetName + "\n"),
+	})
+
+	tests := []struct {
+		name       string
+		missingSig config.MissingSigPolicy
+		validation config.ValidationMode
+		wantErr    error
+		wantMode   config.ValidationMode
+	}{
+		{name: "ShaValidation", validation: config.ShaValidation, wantMode: config.ShaValidation},
+		{name: "NoSignatureWarn", validation: config.SignValidation, wantMode: config.ShaValidation},
+		{name: "NoSignatureFail", missingSig: config.FailMissingSig, validation: config.SignValidation, wantErr: signature.ErrNoSignature},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			conf := retrievertest.DirectConf(t, "tflint", server.URL)
+			conf.MissingSig = test.missingSig
+			conf.Validation = test.validation
+
+			targetPath := filepath.Join(conf.RootPath, "0.55.0")
+			installManifest, err := tflintretriever.Make(conf).InstallWithManifest(t.Context(), "0.55.0", targetPath)
+			if test.wantErr != nil {
+				if !errors.Is(err, test.wantErr) {
+					t.Fatal("Unexpected error, expected", test.wantErr, "get :", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if installManifest.Validation != test.wantMode || installManifest.ArchiveSHA256 != hex.EncodeToString(sum[:]) {
+				t.Error("Unmatching manifest, get :", installManifest)
+			}
+
+			data, err := os.ReadFile(filepath.Join(targetPath, winbin.GetBinaryName("tflint")))
+			if err != nil {
+				t.Fatal("Unexpected error :", err)
+			}
+
+			if string(data) != retrievertest.BinaryContent {
+				t.Error("Unmatching binary content, get :", string(data))
+			}
+		})
+	}
+}
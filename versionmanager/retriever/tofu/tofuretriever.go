@@ -20,6 +20,7 @@ package tofuretriever
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/url"
@@ -34,14 +35,18 @@ import (
 	"github.com/tofuutils/tenv/v4/config/envname"
 	"github.com/tofuutils/tenv/v4/pkg/apimsg"
 	cosigncheck "github.com/tofuutils/tenv/v4/pkg/check/cosign"
-	pgpcheck "github.com/tofuutils/tenv/v4/pkg/check/pgp"
 	sha256check "github.com/tofuutils/tenv/v4/pkg/check/sha256"
 	"github.com/tofuutils/tenv/v4/pkg/download"
 	"github.com/tofuutils/tenv/v4/pkg/github"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
 	"github.com/tofuutils/tenv/v4/pkg/pathfilter"
 	"github.com/tofuutils/tenv/v4/pkg/uncompress"
 	"github.com/tofuutils/tenv/v4/pkg/winbin"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
+	"github.com/tofuutils/tenv/v4/versionmanager/projectlock"
 	htmlretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/html"
+	indexretriever "github.com/tofuutils/tenv/v4/versionmanager/retriever/index"
+	"github.com/tofuutils/tenv/v4/versionmanager/retriever/signature"
 	tofudlmirroring "github.com/tofuutils/tenv/v4/versionmanager/retriever/tofu/dl"
 	tofuurl "github.com/tofuutils/tenv/v4/versionmanager/retriever/tofu/url"
 )
@@ -53,6 +58,7 @@ const (
 
 	defaultTofuURLTemplate = "https://github.com/opentofu/opentofu/releases/download/v{{ .Version }}/{{ .Artifact }}"
 
+	repository   = "opentofu/opentofu"
 	baseIdentity = "https://github.com/opentofu/opentofu/.github/workflows/release.yml@refs/heads/v"
 	issuer       = "https://token.actions.githubusercontent.com"
 	mainIdentity = "https://github.com/opentofu/opentofu/.github/workflows/release.yml@refs/heads/main"
@@ -68,79 +74,105 @@ func Make(conf *config.Config) TofuRetriever {
 	return TofuRetriever{conf: conf}
 }
 
-func (r TofuRetriever) Install(ctx context.Context, versionStr string, targetPath string) error {
+func (r TofuRetriever) Checksum(ctx context.Context, versionStr string, goos string, arch string) (string, error) {
 	err := r.conf.InitRemoteConf()
 	if err != nil {
-		return err
+		return "", err
 	}
 
-	tag := versionStr
-	// assume that opentofu tags start with a 'v'
-	// and version in asset name does not
-	if tag[0] == 'v' {
-		versionStr = versionStr[1:]
-	} else {
-		tag = "v" + versionStr
+	if ctx, err = r.conf.DownloadContext(ctx, r.conf.Tofu); err != nil {
+		return "", err
 	}
 
+	versionStr, tag := splitVersionTag(versionStr)
 	v, err := version.NewVersion(versionStr) //nolint
 	if err != nil {
-		return err
+		return "", err
 	}
 	stable := v.Prerelease() == ""
 
-	var assetURLs []string
-	assetNames := buildAssetNames(versionStr, r.conf.Arch, stable)
-	if r.conf.Displayer.IsDebug() {
-		r.conf.Displayer.Log(hclog.Debug, apimsg.MsgSearch, apimsg.AssetsName, assetNames)
+	assetNames, assetURLs, err := r.assetURLs(ctx, versionStr, tag, goos, arch, stable)
+	if err != nil {
+		return "", err
 	}
 
-	switch r.conf.Tofu.GetInstallMode() {
-	case config.InstallModeDirect:
-		baseAssetURL, err2 := url.JoinPath(r.conf.Tofu.GetRemoteURL(), cmdconst.OpentofuName, cmdconst.OpentofuName, github.Releases, github.Download, tag)
-		if err2 != nil {
-			return err2
-		}
+	requestOptions := indexretriever.RequestOptions(r.conf.Tofu.GetInstallMode(), r.conf.Tofu.GetRemoteURL(), config.GetBasicAuthOption(r.conf.Getenv, envname.TofuRemoteUser, envname.TofuRemotePass))
+	dataSums, err := r.conf.DownloadCache().Bytes(ctx, assetURLs[1], r.conf.Displayer.Display, download.NoCheck, requestOptions...)
+	if err != nil {
+		return "", err
+	}
 
-		assetURLs, err = htmlretriever.BuildAssetURLs(baseAssetURL, assetNames...)
-	case config.ModeAPI:
-		assetURLs, err = github.AssetDownloadURL(ctx, tag, assetNames, r.conf.Tofu.GetRemoteURL(), r.conf.GithubToken, r.conf.Displayer.Display)
-	case modeMirroring:
-		urlTemplate := r.conf.Getenv(envname.TofuURLTemplate)
-		if urlTemplate == "" {
-			urlTemplate = defaultTofuURLTemplate
-		}
+	validation, _, err := r.conf.GetValidation(r.conf.Tofu)
+	if err != nil {
+		return "", err
+	}
 
-		builder, err2 := tofudlmirroring.MakeURLBuilder(urlTemplate, versionStr)
-		if err2 != nil {
-			return err2
+	if validation == config.SignValidation {
+		if _, _, err = r.checkSig(ctx, v, stable, dataSums, assetURLs, requestOptions); err != nil {
+			return "", err
 		}
+	}
 
-		assetURLs, err = download.ApplyURLTransformer(builder.Build, assetNames...)
-	default:
-		return config.ErrInstallMode
+	dataSum, err := sha256check.Extract(dataSums, assetNames[0])
+	if err != nil {
+		return "", err
 	}
+
+	return hex.EncodeToString(dataSum), nil
+}
+
+func (r TofuRetriever) Install(ctx context.Context, versionStr string, targetPath string) error {
+	_, err := r.InstallWithManifest(ctx, versionStr, targetPath)
+
+	return err
+}
+
+func (r TofuRetriever) InstallWithManifest(ctx context.Context, versionStr string, targetPath string) (manifest.Manifest, error) {
+	err := r.conf.InitRemoteConf()
 	if err != nil {
-		return err
+		return manifest.Manifest{}, err
 	}
 
-	assetURLs, err = download.ApplyURLTransformer(r.conf.Tofu.GetRewriteRule(), assetURLs...)
+	if ctx, err = r.conf.DownloadContext(ctx, r.conf.Tofu); err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	versionStr, tag := splitVersionTag(versionStr)
+	v, err := version.NewVersion(versionStr) //nolint
 	if err != nil {
-		return err
+		return manifest.Manifest{}, err
 	}
+	stable := v.Prerelease() == ""
 
-	requestOptions := config.GetBasicAuthOption(r.conf.Getenv, envname.TofuRemoteUser, envname.TofuRemotePass)
-	data, err := download.Bytes(ctx, assetURLs[0], r.conf.Displayer.Display, download.NoCheck, requestOptions...)
+	assetNames, assetURLs, err := r.assetURLs(ctx, versionStr, tag, runtime.GOOS, r.conf.Arch, stable)
 	if err != nil {
-		return err
+		return manifest.Manifest{}, err
 	}
 
+	requestOptions := indexretriever.RequestOptions(r.conf.Tofu.GetInstallMode(), r.conf.Tofu.GetRemoteURL(), config.GetBasicAuthOption(r.conf.Getenv, envname.TofuRemoteUser, envname.TofuRemotePass))
+	file, err := r.conf.DownloadCache().File(ctx, assetURLs[0], r.conf.Displayer.Display, loghelper.ProgressFunc(r.conf.Displayer), download.NoCheck, requestOptions...)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+	defer file.Release()
+
 	fileName := assetNames[0]
-	if err = r.checkSumAndSig(ctx, v, stable, data, fileName, assetURLs, requestOptions); err != nil {
-		return err
+	validation, identity, err := r.checkSumAndSig(ctx, v, stable, file.SHA256, fileName, assetURLs, requestOptions)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	if err = r.conf.CheckValidation(r.conf.Tofu, validation); err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	if err = projectlock.Check(r.conf, cmdconst.TofuName, versionStr, runtime.GOOS, r.conf.Arch, file.SHA256); err != nil {
+		return manifest.Manifest{}, err
 	}
 
-	return uncompress.ToDir(data, fileName, targetPath, pathfilter.NameEqual(winbin.GetBinaryName(cmdconst.TofuName)))
+	installManifest := manifest.Manifest{ArchiveSHA256: hex.EncodeToString(file.SHA256), SignatureIdentity: identity, SourceURL: assetURLs[0], Validation: validation}
+
+	return installManifest, uncompress.ToDir(file.Path, fileName, targetPath, pathfilter.NameEqual(winbin.GetBinaryName(cmdconst.TofuName)))
 }
 
 func (r TofuRetriever) ListVersions(ctx context.Context) ([]string, error) {
@@ -149,9 +181,12 @@ func (r TofuRetriever) ListVersions(ctx context.Context) ([]string, error) {
 		return nil, err
 	}
 
-	requestOptions := config.GetBasicAuthOption(r.conf.Getenv, envname.TofuRemoteUser, envname.TofuRemotePass)
+	if ctx, err = r.conf.DownloadContext(ctx, r.conf.Tofu); err != nil {
+		return nil, err
+	}
 
 	listURL := r.conf.Tofu.GetListURL()
+	requestOptions := indexretriever.RequestOptions(r.conf.Tofu.GetListMode(), listURL, config.GetBasicAuthOption(r.conf.Getenv, envname.TofuRemoteUser, envname.TofuRemotePass))
 	switch r.conf.Tofu.GetListMode() {
 	case config.ListModeHTML:
 		baseURL, err := url.JoinPath(listURL, cmdconst.OpentofuName, cmdconst.OpentofuName, github.Releases, github.Download)
@@ -165,7 +200,7 @@ func (r TofuRetriever) ListVersions(ctx context.Context) ([]string, error) {
 	case config.ModeAPI:
 		r.conf.Displayer.Display(apimsg.MsgFetchAllReleases + listURL)
 
-		return github.ListReleases(ctx, listURL, r.conf.GithubToken)
+		return github.ListReleases(ctx, listURL, r.conf.GithubToken, r.conf.GithubCache())
 	case modeMirroring:
 		if listURL == tofuurl.Github {
 			listURL = defaultTofuMirroringURL
@@ -179,74 +214,161 @@ func (r TofuRetriever) ListVersions(ctx context.Context) ([]string, error) {
 		}
 
 		return tofudlmirroring.ExtractReleases(value)
+	case config.ModeIndex:
+		indexURL, err := indexretriever.IndexURL(listURL, cmdconst.TofuName)
+		if err != nil {
+			return nil, err
+		}
+
+		r.conf.Displayer.Display(apimsg.MsgFetchAllReleases + indexURL)
+
+		return indexretriever.ListReleases(ctx, indexURL, requestOptions)
 	default:
 		return nil, config.ErrListMode
 	}
 }
 
-func (r TofuRetriever) checkSumAndSig(ctx context.Context, version *version.Version, stable bool, data []byte, fileName string, assetURLs []string, options []download.RequestOption) error {
-	if r.conf.Validation == config.NoValidation {
-		return nil
+func (r TofuRetriever) assetURLs(ctx context.Context, versionStr string, tag string, goos string, arch string, stable bool) ([]string, []string, error) {
+	var err error
+	var assetURLs []string
+	assetNames := buildAssetNames(versionStr, goos, arch, stable)
+	if r.conf.Displayer.IsDebug() {
+		r.conf.Displayer.Log(hclog.Debug, apimsg.MsgSearch, apimsg.AssetsName, assetNames)
+	}
+
+	switch r.conf.Tofu.GetInstallMode() {
+	case config.InstallModeDirect:
+		baseAssetURL, err2 := url.JoinPath(r.conf.Tofu.GetRemoteURL(), cmdconst.OpentofuName, cmdconst.OpentofuName, github.Releases, github.Download, tag)
+		if err2 != nil {
+			return nil, nil, err2
+		}
+
+		assetURLs, err = htmlretriever.BuildAssetURLs(baseAssetURL, assetNames...)
+	case config.ModeAPI:
+		assetURLs, err = github.AssetDownloadURL(ctx, tag, assetNames, r.conf.Tofu.GetRemoteURL(), r.conf.GithubToken, r.conf.GithubCache(), r.conf.Displayer.Display)
+	case modeMirroring:
+		urlTemplate := r.conf.Getenv(envname.TofuURLTemplate)
+		if urlTemplate == "" {
+			urlTemplate = defaultTofuURLTemplate
+		}
+
+		builder, err2 := tofudlmirroring.MakeURLBuilder(urlTemplate, versionStr)
+		if err2 != nil {
+			return nil, nil, err2
+		}
+
+		assetURLs, err = download.ApplyURLTransformer(builder.Build, assetNames...)
+	case config.ModeIndex:
+		indexURL, err2 := indexretriever.IndexURL(r.conf.Tofu.GetRemoteURL(), cmdconst.TofuName)
+		if err2 != nil {
+			return nil, nil, err2
+		}
+
+		r.conf.Displayer.Display(apimsg.MsgFetchRelease + indexURL)
+
+		requestOptions := indexretriever.RequestOptions(r.conf.Tofu.GetInstallMode(), r.conf.Tofu.GetRemoteURL(), config.GetBasicAuthOption(r.conf.Getenv, envname.TofuRemoteUser, envname.TofuRemotePass))
+		var indexURLs []string
+		if assetNames[0], indexURLs, err = indexretriever.AssetURLs(ctx, indexURL, versionStr, goos, arch, requestOptions); err != nil {
+			return nil, nil, err
+		}
+
+		// signature files are expected next to checksums file (certificate, cosign and pgp signatures)
+		assetURLs = indexURLs[:2]
+		for _, assetName := range assetNames[2:] {
+			assetURLs = append(assetURLs, indexURLs[1]+strings.TrimPrefix(assetName, assetNames[1]))
+		}
+	default:
+		return nil, nil, config.ErrInstallMode
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	assetURLs, err = download.ApplyURLTransformer(r.conf.Tofu.GetRewriteRule(), assetURLs...)
+
+	return assetNames, assetURLs, err
+}
+
+func (r TofuRetriever) checkSumAndSig(ctx context.Context, version *version.Version, stable bool, sum []byte, fileName string, assetURLs []string, options []download.RequestOption) (config.ValidationMode, string, error) {
+	validation, _, err := r.conf.GetValidation(r.conf.Tofu)
+	if err != nil || validation == config.NoValidation {
+		return config.NoValidation, "", err
 	}
 
-	dataSums, err := download.Bytes(ctx, assetURLs[1], r.conf.Displayer.Display, download.NoCheck, options...)
+	dataSums, err := r.conf.DownloadCache().Bytes(ctx, assetURLs[1], r.conf.Displayer.Display, download.NoCheck, options...)
 	if err != nil {
-		return err
+		return 0, "", err
 	}
 
-	if err = sha256check.Check(data, dataSums, fileName); err != nil {
-		return err
+	if err = sha256check.CheckSum(sum, dataSums, fileName); err != nil {
+		return 0, "", err
 	}
 
-	if r.conf.Validation == config.ShaValidation {
-		return nil
+	switch validation {
+	case config.AttestationValidation:
+		return signature.CheckAttestation(ctx, r.conf, r.conf.Tofu, repository, r.conf.Tofu.GetReleaseWorkflow(config.DefaultReleaseWorkflow), sum)
+	case config.ShaValidation:
+		return config.ShaValidation, "", nil
 	}
 
-	dataSumsSig, err := download.Bytes(ctx, assetURLs[3], r.conf.Displayer.Display, download.NoCheck, options...)
+	return r.checkSig(ctx, version, stable, dataSums, assetURLs, options)
+}
+
+// return the validation mode really applied (signature check can be skipped) and the signature identity.
+func (r TofuRetriever) checkSig(ctx context.Context, version *version.Version, stable bool, dataSums []byte, assetURLs []string, options []download.RequestOption) (config.ValidationMode, string, error) {
+	dataSumsSig, err := r.conf.DownloadCache().Bytes(ctx, assetURLs[3], r.conf.Displayer.Display, download.NoCheck, options...)
 	if err != nil {
-		return err
+		return 0, "", err
 	}
 
-	dataSumsCert, err := download.Bytes(ctx, assetURLs[2], r.conf.Displayer.Display, download.NoCheck, options...)
+	dataSumsCert, err := r.conf.DownloadCache().Bytes(ctx, assetURLs[2], r.conf.Displayer.Display, download.NoCheck, options...)
 	if err != nil {
-		return err
+		return 0, "", err
 	}
 
+	// cosign executable also checks Rekor transparency log inclusion, unlike in process check
 	identity := buildIdentity(version)
 	err = cosigncheck.Check(ctx, dataSums, dataSumsSig, dataSumsCert, identity, issuer, r.conf.Displayer)
 	if err == nil || !errors.Is(err, cosigncheck.ErrNotInstalled) {
-		return err
+		return config.SignValidation, manifest.CosignIdentity(identity, issuer), err
 	}
 
+	trustedRoot, err := cosigncheck.TrustedRoot(r.conf.SigstoreRoot, r.conf.SigstoreCachePath(), r.conf.Offline, r.conf.HTTPClient)
+	if err == nil {
+		r.conf.Displayer.Display("cosign executable not found, signature checked in process without transparency log inclusion")
+		err = cosigncheck.CheckNative(dataSums, dataSumsSig, dataSumsCert, identity, issuer, trustedRoot)
+
+		return config.SignNoTlogValidation, manifest.CosignIdentity(identity, issuer), err
+	}
+
+	r.conf.Displayer.Display(loghelper.Concat("Sigstore trusted root not available (", err.Error(), ")"))
+
 	if !stable {
 		r.conf.Displayer.Display("skip signature check : cosign executable not found and pgp check not available for unstable version")
 
-		return nil
+		return config.ShaValidation, "", nil
 	}
 
 	r.conf.Displayer.Display("cosign executable not found, fallback to pgp check")
 
-	dataSumsSig, err = download.Bytes(ctx, assetURLs[4], r.conf.Displayer.Display, download.NoCheck, options...)
+	dataSumsSig, err = r.conf.DownloadCache().Bytes(ctx, assetURLs[4], r.conf.Displayer.Display, download.NoCheck, options...)
 	if err != nil {
-		return err
+		return 0, "", err
 	}
 
-	dataPublicKey, err := download.GetPGPKey(ctx, r.conf.TofuKeyPathOrURL, r.conf.Displayer.Display)
-	if err != nil {
-		return err
-	}
+	fingerprint, err := signature.CheckPGP(ctx, r.conf, cmdconst.TofuName, r.conf.TofuKeyPathOrURL, dataSums, dataSumsSig)
 
-	return pgpcheck.Check(dataSums, dataSumsSig, dataPublicKey)
+	return config.SignValidation, manifest.PGPIdentity(fingerprint), err
 }
 
-func buildAssetNames(version string, arch string, stable bool) []string {
+func buildAssetNames(version string, goos string, arch string, stable bool) []string {
 	var nameBuilder strings.Builder
 	nameBuilder.WriteString(baseFileName)
 	nameBuilder.WriteString(version)
 	nameBuilder.WriteByte('_')
 	sumsAssetName := nameBuilder.String() + "SHA256SUMS"
 
-	nameBuilder.WriteString(runtime.GOOS)
+	nameBuilder.WriteString(goos)
 	nameBuilder.WriteByte('_')
 	nameBuilder.WriteString(arch)
 	nameBuilder.WriteString(".zip")
@@ -274,3 +396,13 @@ func buildIdentity(version *version.Version) string {
 
 	return baseIdentity + shortVersion
 }
+
+// assume that opentofu tags start with a 'v'
+// and version in asset name does not.
+func splitVersionTag(versionStr string) (string, string) {
+	if versionStr[0] == 'v' {
+		return versionStr[1:], versionStr
+	}
+
+	return versionStr, "v" + versionStr
+}
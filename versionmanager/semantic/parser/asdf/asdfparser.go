@@ -55,6 +55,25 @@ func RetrieveAtmosVersion(filePath string, conf *config.Config) (string, error)
 	return retrieveVersionFromToolFile(filePath, cmdconst.AtmosName, conf)
 }
 
+func RetrieveTflintVersion(filePath string, conf *config.Config) (string, error) {
+	return retrieveVersionFromToolFile(filePath, cmdconst.TflintName, conf)
+}
+
+func RetrieveTfDocsVersion(filePath string, conf *config.Config) (string, error) {
+	return retrieveVersionFromToolFile(filePath, cmdconst.TerraformDocsName, conf)
+}
+
+func RetrieveInfracostVersion(filePath string, conf *config.Config) (string, error) {
+	return retrieveVersionFromToolFile(filePath, cmdconst.InfracostName, conf)
+}
+
+// ToolVersionRetriever returns a parser searching toolName version in asdf tool file.
+func ToolVersionRetriever(toolName string) func(string, *config.Config) (string, error) {
+	return func(filePath string, conf *config.Config) (string, error) {
+		return retrieveVersionFromToolFile(filePath, toolName, conf)
+	}
+}
+
 func retrieveVersionFromToolFile(filePath, toolName string, conf *config.Config) (string, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
@@ -49,8 +49,32 @@ var versionPartialSchema = &hcl.BodySchema{ //nolint
 }
 
 func GatherRequiredVersion(conf *config.Config, exts []ExtDescription) ([]string, error) {
+	var requiredVersions []string
+	if err := parseFiles(conf, exts, func(body hcl.Body) {
+		requiredVersions = append(requiredVersions, extractRequiredVersion(body, conf)...)
+	}); err != nil {
+		return nil, err
+	}
+
+	return requiredVersions, nil
+}
+
+// GatherRequiredProviders returns entries of required_providers blocks found in IAC files of working directory.
+func GatherRequiredProviders(conf *config.Config, exts []ExtDescription) ([]ProviderRequirement, error) {
+	var requirements []ProviderRequirement
+	if err := parseFiles(conf, exts, func(body hcl.Body) {
+		requirements = append(requirements, extractRequiredProviders(body, conf)...)
+	}); err != nil {
+		return nil, err
+	}
+
+	return requirements, nil
+}
+
+// call extract on each IAC file of working directory (when several files differ only by their extension, the first in exts is used).
+func parseFiles(conf *config.Config, exts []ExtDescription, extract func(hcl.Body)) error {
 	if len(exts) == 0 {
-		return nil, nil
+		return nil
 	}
 
 	conf.Displayer.Display("Scan project to find IAC files")
@@ -68,7 +92,7 @@ func GatherRequiredVersion(conf *config.Config, exts []ExtDescription) ([]string
 
 	entries, err := os.ReadDir(conf.WorkPath)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	similar := map[string]int{}
@@ -88,7 +112,6 @@ func GatherRequiredVersion(conf *config.Config, exts []ExtDescription) ([]string
 		}
 	}
 
-	var requiredVersions []string
 	var parsedFile *hcl.File
 	var diags hcl.Diagnostics
 	foundFiles = make([]string, 0, len(similar))
@@ -99,17 +122,16 @@ func GatherRequiredVersion(conf *config.Config, exts []ExtDescription) ([]string
 
 		parsedFile, diags = ext.Parser(filepath.Join(conf.WorkPath, name))
 		if diags.HasErrors() {
-			return nil, diags
+			return diags
 		}
 		if parsedFile == nil {
 			continue
 		}
 
-		extracted := extractRequiredVersion(parsedFile.Body, conf)
-		requiredVersions = append(requiredVersions, extracted...)
+		extract(parsedFile.Body)
 	}
 
-	return requiredVersions, nil
+	return nil
 }
 
 func extractRequiredVersion(body hcl.Body, conf *config.Config) []string {
@@ -0,0 +1,125 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package iacparser
+
+import (
+	"github.com/hashicorp/go-hclog"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+)
+
+const (
+	requiredProvidersName = "required_providers"
+	sourceName            = "source"
+	versionName           = "version"
+)
+
+// ProviderRequirement is an entry of a required_providers block.
+type ProviderRequirement struct {
+	Name    string // local name
+	Source  string // empty when not specified
+	Version string // version constraint, empty when not specified
+}
+
+var providersPartialSchema = &hcl.BodySchema{ //nolint
+	Blocks: []hcl.BlockHeaderSchema{{Type: requiredProvidersName}},
+}
+
+func extractRequiredProviders(body hcl.Body, conf *config.Config) []ProviderRequirement {
+	rootContent, _, diags := body.PartialContent(terraformPartialSchema)
+	if diags.HasErrors() {
+		conf.Displayer.Log(hclog.Warn, "Failed to parse hcl file", loghelper.Error, diags)
+
+		return nil
+	}
+
+	var requirements []ProviderRequirement
+	for _, block := range rootContent.Blocks {
+		content, _, diags := block.Body.PartialContent(providersPartialSchema)
+		if diags.HasErrors() {
+			conf.Displayer.Log(hclog.Warn, "Failed to parse hcl block", loghelper.Error, diags)
+
+			return nil
+		}
+
+		for _, providersBlock := range content.Blocks {
+			attrs, diags := providersBlock.Body.JustAttributes()
+			if diags.HasErrors() {
+				conf.Displayer.Log(hclog.Warn, "Failed to parse hcl block", loghelper.Error, diags)
+
+				continue
+			}
+
+			for name, attr := range attrs {
+				requirements = append(requirements, parseRequirement(name, attr.Expr, conf))
+			}
+		}
+	}
+
+	return requirements
+}
+
+// handle object form (other keys like configuration_aliases are ignored) and legacy version string form.
+func parseRequirement(name string, expr hcl.Expression, conf *config.Config) ProviderRequirement {
+	requirement := ProviderRequirement{Name: name}
+
+	pairs, diags := hcl.ExprMap(expr)
+	if diags.HasErrors() {
+		requirement.Version = exprString(expr, conf)
+
+		return requirement
+	}
+
+	for _, pair := range pairs {
+		key := hcl.ExprAsKeyword(pair.Key)
+		if key == "" {
+			key = exprString(pair.Key, conf)
+		}
+
+		switch key {
+		case sourceName:
+			requirement.Source = exprString(pair.Value, conf)
+		case versionName:
+			requirement.Version = exprString(pair.Value, conf)
+		}
+	}
+
+	return requirement
+}
+
+// return an empty string when expr is not a known string.
+func exprString(expr hcl.Expression, conf *config.Config) string {
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		conf.Displayer.Log(hclog.Warn, "Failed to parse hcl attribute", loghelper.Error, diags)
+
+		return ""
+	}
+
+	val, err := convert.Convert(val, cty.String)
+	if err != nil || val.IsNull() || !val.IsWhollyKnown() {
+		return ""
+	}
+
+	return val.AsString()
+}
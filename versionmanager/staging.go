@@ -0,0 +1,211 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package versionmanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+
+	"github.com/tofuutils/tenv/v4/config"
+	"github.com/tofuutils/tenv/v4/pkg/fileperm"
+	"github.com/tofuutils/tenv/v4/pkg/loghelper"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
+)
+
+const (
+	// CompleteMarkerName is written last in a staged version directory, before its move to final location.
+	CompleteMarkerName = ".tenv-complete"
+
+	hiddenPrefix        = "."
+	lastUseFileName     = "last-use.txt"
+	refreshStagingDelay = 5 * time.Minute // far below staleStagingDelay
+	stagingPrefix       = hiddenPrefix + "install-"
+	stagingDirPattern   = stagingPrefix + "*"
+	staleStagingDelay   = time.Hour
+)
+
+// remove staging directories left by interrupted installations (must be called under lock,
+// recent ones could belong to an ongoing parallel installation, which refreshes their modification time).
+func (m VersionManager) cleanStaleStaging(installPath string) {
+	entries, err := os.ReadDir(installPath)
+	if err != nil {
+		return
+	}
+
+	limit := time.Now().Add(-staleStagingDelay)
+	for _, entry := range entries {
+		if !entry.IsDir() || !isStaging(entry.Name()) {
+			continue
+		}
+
+		if info, err := entry.Info(); err != nil || info.ModTime().After(limit) {
+			continue
+		}
+
+		stagingPath := filepath.Join(installPath, entry.Name())
+		if err = os.RemoveAll(stagingPath); err != nil {
+			m.Conf.Displayer.Log(hclog.Warn, "Can not remove interrupted installation", "path", stagingPath, loghelper.Error, err)
+		}
+	}
+}
+
+// write manifest then completion marker.
+func (m VersionManager) completeStaging(stagingPath string, version string, installManifest manifest.Manifest) error {
+	files, err := hashInstalledFiles(stagingPath)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	installManifest.Files = files
+	installManifest.InstalledAt = now
+	installManifest.TenvVersion = manifest.TenvVersion(m.Conf)
+	installManifest.Tool = m.FolderName
+	installManifest.Version = version
+	if err = manifest.Write(stagingPath, installManifest); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(stagingPath, CompleteMarkerName), now.AppendFormat(nil, time.RFC3339), fileperm.RW)
+}
+
+// move a complete staged version to its final location (must be called under lock),
+// replace an incomplete directory left by an interrupted installation.
+func (m VersionManager) moveStaged(stagingPath string, installPath string, version string) error {
+	targetPath := filepath.Join(installPath, version)
+	if IncompleteInstall(targetPath) {
+		m.Conf.Displayer.Log(hclog.Warn, "Replace incomplete installation", "path", targetPath)
+		if err := os.RemoveAll(targetPath); err != nil {
+			return err
+		}
+	}
+
+	err := os.Rename(stagingPath, targetPath)
+	if err == nil {
+		m.Conf.Displayer.Display(loghelper.Concat("Installation of ", m.FolderName, " ", version, " successful"))
+	}
+
+	return err
+}
+
+// install version in a new staging directory, the caller must call the returned function once it is moved
+// (stop its modification time refresh and remove it when it is still there).
+func (m VersionManager) stageVersion(ctx context.Context, installPath string, version string) (string, func(), error) {
+	stagingPath, err := os.MkdirTemp(installPath, stagingDirPattern)
+	if err != nil {
+		return "", nil, err
+	}
+
+	stopRefresh := refreshStaging(stagingPath)
+	release := func() {
+		stopRefresh()
+		os.RemoveAll(stagingPath)
+	}
+
+	var installManifest manifest.Manifest
+	switch manifestRetriever, ok := m.retriever.(ManifestRetriever); {
+	case ok:
+		installManifest, err = manifestRetriever.InstallWithManifest(ctx, version, stagingPath)
+	case m.Conf.StrictValidation:
+		err = fmt.Errorf("%w (checks done by %s retriever are unknown)", config.ErrValidationDowngrade, m.FolderName)
+	default:
+		installManifest.Validation = config.UnknownValidation
+		err = m.retriever.Install(ctx, version, stagingPath)
+	}
+
+	if err == nil {
+		m.Conf.Displayer.Display(loghelper.Concat(m.FolderName, " ", version, " checks : ", installManifest.ChecksSummary()))
+		err = m.completeStaging(stagingPath, version, installManifest)
+	}
+
+	if err != nil {
+		release()
+
+		return "", nil, err
+	}
+
+	return stagingPath, release, nil
+}
+
+// IncompleteInstall reports an existing version directory left by an interrupted installation.
+// Versions installed before completion marker existence are considered complete,
+// unless nothing was extracted (only last use date can be written after installation).
+func IncompleteInstall(versionPath string) bool {
+	if _, err := os.Stat(filepath.Join(versionPath, CompleteMarkerName)); err == nil {
+		return false
+	}
+
+	entries, err := os.ReadDir(versionPath)
+	if err != nil {
+		return false // not an existing directory
+	}
+
+	for _, entry := range entries {
+		if entry.Name() != lastUseFileName {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ignore files written by tenv.
+func hashInstalledFiles(versionPath string) (map[string]string, error) {
+	return manifest.HashFiles(versionPath, manifest.FileName, CompleteMarkerName, lastUseFileName)
+}
+
+// version directories are never hidden.
+func isHidden(name string) bool {
+	return strings.HasPrefix(name, hiddenPrefix)
+}
+
+func isStaging(name string) bool {
+	return strings.HasPrefix(name, stagingPrefix)
+}
+
+// periodically update stagingPath modification time until the returned function is called.
+func refreshStaging(stagingPath string) func() {
+	done, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(refreshStagingDelay)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case now := <-ticker.C:
+				os.Chtimes(stagingPath, now, now)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/tofuutils/tenv/v4/pkg/loghelper"
 	"github.com/tofuutils/tenv/v4/versionmanager"
 	"github.com/tofuutils/tenv/v4/versionmanager/builder"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
 	"github.com/tofuutils/tenv/v4/versionmanager/proxy"
 )
 
@@ -43,6 +44,7 @@ type tenvConfig struct {
 	hclParser      *hclparse.Parser
 	ignoreEnv      bool
 	initConfigFunc func() (config.Config, error)
+	progressFunc   func(loghelper.Progress)
 }
 
 type TenvOption func(*tenvConfig)
@@ -84,6 +86,13 @@ func WithHCLParser(hclParser *hclparse.Parser) TenvOption {
 	}
 }
 
+// Receive download progress events (bytes received, total size when known and rate), even when display is disabled.
+func WithProgress(progressFunc func(loghelper.Progress)) TenvOption {
+	return func(tc *tenvConfig) {
+		tc.progressFunc = progressFunc
+	}
+}
+
 // Not concurrent safe.
 type Tenv struct {
 	builders  map[string]builder.Func
@@ -131,6 +140,10 @@ func Make(options ...TenvOption) (Tenv, error) {
 		wrapperConf.conf.Displayer = wrapperConf.displayer
 	}
 
+	if wrapperConf.progressFunc != nil {
+		wrapperConf.conf.Displayer = loghelper.WithProgressFunc(wrapperConf.conf.Displayer, wrapperConf.progressFunc)
+	}
+
 	if wrapperConf.hclParser == nil {
 		wrapperConf.hclParser = hclparse.NewParser()
 	}
@@ -222,6 +235,21 @@ func (t Tenv) Evaluate(ctx context.Context, toolName string, requestedVersion st
 	return manager.Evaluate(ctx, requestedVersion, false)
 }
 
+// Read provenance manifest of an installed version (manifest.ErrNoManifest for versions installed by older tenv),
+// fail with ctx error when it is already canceled.
+func (t Tenv) Info(ctx context.Context, toolName string, version string) (manifest.Manifest, error) {
+	if err := ctx.Err(); err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	manager, err := t.getManager(toolName)
+	if err != nil {
+		return manifest.Manifest{}, err
+	}
+
+	return manager.Info(version)
+}
+
 func (t Tenv) Install(ctx context.Context, toolName string, requestedVersion string) error {
 	manager, err := t.getManager(toolName)
 	if err != nil {
@@ -0,0 +1,111 @@
+/*
+ *
+ * Copyright 2026 tofuutils authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package versionmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/tofuutils/tenv/v4/pkg/download"
+	"github.com/tofuutils/tenv/v4/versionmanager/manifest"
+)
+
+var (
+	ErrNoRecordedFiles     = errors.New("no recorded file hashes in manifest")
+	ErrTampered            = errors.New("installed files do not match manifest")
+	ErrUpstreamSumChange   = errors.New("recorded archive checksum does not match upstream one")
+	ErrUpstreamUnreachable = errors.New("upstream checksum not reachable")
+)
+
+// Verify recomputes hashes of an installed version files and compares them to its manifest.
+// With upstream true, recorded archive checksum is also compared to upstream one
+// (the checksums file signature is checked depending on validation mode),
+// ErrNoChecksum is returned after files check when the tool retriever can not give it,
+// and ErrUpstreamUnreachable when it can not be downloaded (offline mode or network failure).
+func (m VersionManager) Verify(ctx context.Context, version string, upstream bool) error {
+	installManifest, err := m.Info(version)
+	if err != nil {
+		return err
+	}
+
+	if len(installManifest.Files) == 0 {
+		return ErrNoRecordedFiles
+	}
+
+	installPath, err := m.InstallPath()
+	if err != nil {
+		return err
+	}
+
+	files, err := hashInstalledFiles(filepath.Join(installPath, installManifest.Version))
+	if err != nil {
+		return err
+	}
+
+	var changes []string
+	for relPath, expected := range installManifest.Files {
+		switch sum, ok := files[relPath]; {
+		case !ok:
+			changes = append(changes, relPath+" missing")
+		case sum != expected:
+			changes = append(changes, relPath+" modified")
+		}
+	}
+
+	for relPath := range files {
+		if _, ok := installManifest.Files[relPath]; !ok {
+			changes = append(changes, relPath+" added")
+		}
+	}
+
+	if len(changes) != 0 {
+		slices.Sort(changes)
+
+		return fmt.Errorf("%w : %s", ErrTampered, strings.Join(changes, ", "))
+	}
+
+	if !upstream {
+		return nil
+	}
+
+	upstreamSum, err := m.Checksum(ctx, installManifest.Version, runtime.GOOS, m.Conf.Arch)
+	if err != nil {
+		if download.IsNetworkError(err) {
+			return fmt.Errorf("%w : %w", ErrUpstreamUnreachable, err)
+		}
+
+		return err
+	}
+
+	if upstreamSum != installManifest.ArchiveSHA256 {
+		return fmt.Errorf("%w (recorded %s, upstream %s)", ErrUpstreamSumChange, installManifest.ArchiveSHA256, upstreamSum)
+	}
+
+	return nil
+}
+
+// IsUnverifiable reports errors coming from missing install record or upstream checksum (rather than a failed check).
+func IsUnverifiable(err error) bool {
+	return errors.Is(err, manifest.ErrNoManifest) || errors.Is(err, ErrNoRecordedFiles) || errors.Is(err, ErrNoChecksum) || errors.Is(err, ErrUpstreamUnreachable)
+}